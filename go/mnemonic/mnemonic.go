@@ -0,0 +1,271 @@
+/*
+Encode secret bytes (keys, recovery seeds) as a list of words which are
+easy to write down and read back.
+
+Every word carries 10 bits.  A final checksum word, computed like
+type1.CalcCheckword() from the SHA-256 of the secret, detects typos.
+*/
+package mnemonic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/util"
+	"github.com/cruxic/passillion/go/wordlist"
+	"io"
+	"sort"
+	"strings"
+)
+
+const BitsPerWord = 10
+
+//Longest secret Encode() accepts
+const MaxSecretLen = 1024
+
+var errWordCount = errors.New("wrong number of words")
+
+var gWords []string
+var gIndex map[string]int
+
+//Built before use so concurrent callers never see a partial list.
+func init() {
+	all, err := wordlist.Get(wordlist.Standard)
+	if err != nil || len(all) < 1 << BitsPerWord {
+		panic("mnemonic: standard word list is too short")
+	}
+
+	words := make([]string, 1 << BitsPerWord)
+	copy(words, all)
+	sort.Strings(words)
+
+	gIndex = make(map[string]int)
+	for i, word := range words {
+		gIndex[word] = i
+	}
+	gWords = words
+}
+
+/*
+The 1024 mnemonic words: the first 1024 words of the builtin standard list,
+sorted.  Changing them would break every mnemonic ever written down.
+*/
+func Words() []string {
+	return gWords
+}
+
+/*
+The checksum word is the first 9 bits of SHA-256(secret) followed by 1 bit
+which is set when the last data word holds 8 bits of padding.  Without that
+bit a 4 byte secret and a 5 byte secret ending in zero would both be 4 data
+words.
+*/
+func checksum(secret []byte, extraPad bool) int {
+	hash := sha256.Sum256(secret)
+	c := (int(hash[0]) << 1) | int(hash[1] >> 7)
+	c <<= 1
+	if extraPad {
+		c |= 1
+	}
+	return c
+}
+
+/*
+Encode the secret as words.  The last word is the checksum.
+*/
+func Encode(secret []byte) ([]string, error) {
+	if len(secret) == 0 || len(secret) > MaxSecretLen {
+		return nil, fmt.Errorf("secret must be 1 to %d bytes", MaxSecretLen)
+	}
+
+	words := Words()
+
+	nBits := len(secret) * 8
+	nData := (nBits + BitsPerWord - 1) / BitsPerWord
+	pad := nData * BitsPerWord - nBits
+
+	res := make([]string, 0, nData + 1)
+	br := util.NewBitReader(bytes.NewReader(secret))
+	for i := 0; i < nData; i++ {
+		//The last word is padded with zeros (and io.EOF is returned)
+		v, err := br.ReadBits(BitsPerWord)
+		if err != nil && !(err == io.EOF && i == nData - 1) {
+			return nil, err
+		}
+		res = append(res, words[v])
+	}
+
+	res = append(res, words[checksum(secret, pad >= 8)])
+
+	return res, nil
+}
+
+/*
+Returned by Decode() when a word cannot be right.
+*/
+type WordError struct {
+	//1 based position of the wrong word.  0 if it cannot be determined.
+	Position int
+
+	//What was typed
+	Word string
+
+	//Similar words which would make the mnemonic valid
+	Suggestions []string
+
+	Message string
+}
+
+func (self *WordError) Error() string {
+	s := self.Message
+	if self.Position > 0 {
+		s = fmt.Sprintf("word %d \"%s\" %s", self.Position, self.Word, self.Message)
+	}
+	if len(self.Suggestions) > 0 {
+		s += " (did you mean " + strings.Join(self.Suggestions, " or ") + "?)"
+	}
+	return s
+}
+
+//Pack the data words back into bytes.  Fails if the padding is not zero.
+func unpack(values []int, extraPad bool) ([]byte, error) {
+	nBits := len(values) * BitsPerWord
+	nBytes := nBits / 8
+	if extraPad {
+		nBytes--
+	}
+
+	if nBytes < 1 || (nBytes * 8 + BitsPerWord - 1) / BitsPerWord != len(values) {
+		return nil, errWordCount
+	}
+
+	res := make([]byte, nBytes)
+	bit := 0
+	for _, v := range values {
+		for k := BitsPerWord - 1; k >= 0; k-- {
+			b := (v >> uint(k)) & 1
+			if bit < nBytes * 8 {
+				res[bit / 8] |= byte(b << uint(7 - bit % 8))
+			} else if b != 0 {
+				return nil, errors.New("padding is not zero")
+			}
+			bit++
+		}
+	}
+
+	return res, nil
+}
+
+//Decode word indices.  The last one is the checksum.
+func decodeValues(values []int) ([]byte, error) {
+	n := len(values) - 1
+	extraPad := values[n] & 1 == 1
+
+	secret, err := unpack(values[0:n], extraPad)
+	if err != nil {
+		return nil, err
+	}
+
+	if checksum(secret, extraPad) != values[n] {
+		util.Erase(secret)
+		return nil, errors.New("checksum mismatch")
+	}
+
+	return secret, nil
+}
+
+/*
+Find substitutions for each word, one edit away from what was typed, which
+make the checksum valid.  The checksum is only 9 bits so a wider search
+would mostly find coincidences.
+*/
+func suggest(values []int, typed []string) *WordError {
+	words := Words()
+
+	var found []WordError
+	for pos := range values {
+		var sugg []string
+		orig := values[pos]
+		for i, candidate := range words {
+			if i == orig || wordlist.EditDistance(candidate, typed[pos]) > 1 {
+				continue
+			}
+
+			values[pos] = i
+			if secret, err := decodeValues(values); err == nil {
+				util.Erase(secret)
+				sugg = append(sugg, candidate)
+			}
+		}
+		values[pos] = orig
+
+		if len(sugg) > 0 {
+			found = append(found, WordError{
+				Position: pos + 1,
+				Word: typed[pos],
+				Suggestions: sugg,
+				Message: "may be wrong",
+			})
+		}
+	}
+
+	if len(found) == 1 {
+		return &found[0]
+	}
+
+	return &WordError{Message: "checksum mismatch: a word is wrong, missing or extra"}
+}
+
+/*
+Decode words from Encode().  Case and surrounding white space are ignored.
+On failure the error is a *WordError which, when possible, tells which word
+is wrong and suggests corrections.
+*/
+func Decode(typed []string) ([]byte, error) {
+	if len(typed) < 2 {
+		return nil, &WordError{Message: "too few words"}
+	}
+
+	values := make([]int, len(typed))
+	norm := make([]string, len(typed))
+	for i, word := range typed {
+		word = strings.ToLower(strings.TrimSpace(word))
+		norm[i] = word
+
+		v, ok := gIndex[word]
+		if !ok {
+			//suggest the closest words
+			var sugg []string
+			best := 3
+			for _, candidate := range gWords {
+				d := wordlist.EditDistance(candidate, word)
+				if d < best {
+					best = d
+					sugg = nil
+				}
+				if d == best {
+					sugg = append(sugg, candidate)
+				}
+			}
+
+			return nil, &WordError{
+				Position: i + 1,
+				Word: word,
+				Suggestions: sugg,
+				Message: "is not a mnemonic word",
+			}
+		}
+		values[i] = v
+	}
+
+	secret, err := decodeValues(values)
+	if err != nil {
+		if err == errWordCount {
+			return nil, &WordError{Message: err.Error()}
+		}
+		return nil, suggest(values, norm)
+	}
+
+	return secret, nil
+}
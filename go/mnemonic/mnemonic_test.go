@@ -0,0 +1,100 @@
+package mnemonic
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/cruxic/passillion/go/util"
+	"github.com/cruxic/passillion/go/wordlist"
+	"encoding/hex"
+	"strings"
+)
+
+func Test_Words(t *testing.T) {
+	assert := assert.New(t)
+
+	words := Words()
+	assert.Equal(1024, len(words))
+	for i := 1; i < len(words); i++ {
+		assert.True(words[i-1] < words[i])
+		assert.False(strings.ContainsAny(words[i], " \t"))
+	}
+
+	//Must never change
+	assert.Equal("6c4442053390362ce81e25ea87ede0966606bed3dcac46743f2b7cb8c6398d06", wordlist.Hash(words))
+}
+
+func Test_EncodeDecode(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Encode(nil)
+	assert.Error(err)
+	_, err = Encode(make([]byte, MaxSecretLen + 1))
+	assert.Error(err)
+
+	//every length which needs a different amount of padding, including
+	// 4 and 5 bytes which have the same number of data words
+	for n := 1; n <= 33; n++ {
+		secret := util.ByteSequence(byte(n), n)
+		words, err := Encode(secret)
+		assert.NoError(err)
+		assert.Equal((n * 8 + 9) / 10 + 1, len(words))
+
+		got, err := Decode(words)
+		assert.NoError(err)
+		assert.Equal(secret, got, n)
+	}
+
+	four, _ := Encode([]byte{1, 2, 3, 4})
+	five, _ := Encode([]byte{1, 2, 3, 4, 0})
+	assert.Equal(len(four), len(five))
+	assert.NotEqual(four, five)
+
+	//Known answer
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	words, err := Encode(seed)
+	assert.NoError(err)
+	assert.Equal("able anti clap scat awry bunt join akin beak dark scar fess bled safe", strings.Join(words, " "))
+
+	//case and white space are ignored
+	upper := make([]string, len(words))
+	for i, word := range words {
+		upper[i] = " " + strings.ToUpper(word) + "\n"
+	}
+	got, err := Decode(upper)
+	assert.NoError(err)
+	assert.Equal(seed, got)
+}
+
+func Test_DecodeErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	words, _ := Encode(seed)
+
+	_, err := Decode(words[0:1])
+	assert.Error(err)
+
+	//missing word
+	_, err = Decode(words[1:])
+	assert.Error(err)
+
+	//not a mnemonic word
+	bad := append([]string{}, words...)
+	bad[3] = bad[3] + "x"
+	_, err = Decode(bad)
+	we := err.(*WordError)
+	assert.Equal(4, we.Position)
+	assert.Contains(we.Suggestions, words[3])
+	assert.Contains(we.Error(), "word 4 \"" + bad[3] + "\" is not a mnemonic word")
+
+	//a valid word which is a typo of the right one is found with the checksum
+	bad = append([]string{}, words...)
+	bad[2] = "slap"
+	_, err = Decode(bad)
+	assert.Equal("word 3 \"slap\" may be wrong (did you mean clap?)", err.Error())
+
+	//too many typos to tell
+	bad[9] = "mark"
+	_, err = Decode(bad)
+	assert.Equal(0, err.(*WordError).Position)
+}
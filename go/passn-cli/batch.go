@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/cruxic/passillion/go/sitecache"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"golang.org/x/crypto/ssh/terminal"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+//One line of JSONL input and output
+type batchRecord struct {
+	Site string `json:"site"`
+	Personalization string `json:"personalization,omitempty"`
+	Coordinates []string `json:"coordinates,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+/*
+Read sites from a CSV file (sitename,personalization) or JSONL file
+({"site": ..., "personalization": ...}).  Lines starting with # are ignored in CSV.
+*/
+func readBatchSites(r io.Reader, format string) ([]type1.SiteInput, error) {
+	var sites []type1.SiteInput
+
+	if format == "jsonl" {
+		dec := json.NewDecoder(r)
+		for {
+			var rec batchRecord
+			err := dec.Decode(&rec)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("site %d: %s", len(sites) + 1, err.Error())
+			}
+			sites = append(sites, type1.SiteInput{Sitename: rec.Site, Personalization: rec.Personalization})
+		}
+		return sites, nil
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("site %d: expected sitename,personalization", len(sites) + 1)
+		}
+
+		site := type1.SiteInput{Sitename: fields[0]}
+		if len(fields) == 2 {
+			site.Personalization = fields[1]
+		}
+		sites = append(sites, site)
+	}
+
+	return sites, nil
+}
+
+/*
+Prompt for the coordinate password on stderr (so that stdout only has the
+results) and return it as bytes which the caller must erase.
+*/
+func securePromptBytes(message string, isValid func(string) error) []byte {
+	if gPinentry != "" {
+		return pinentryPrompt(message, isValid)
+	}
+
+	for {
+		fmt.Fprintf(os.Stderr, "%s: ", message)
+		rawPass, err := terminal.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			log.Fatal("error reading password (stdin must be a terminal)")
+		}
+		fmt.Fprintln(os.Stderr)
+
+		pass := append([]byte(nil), bytes.TrimSpace(rawPass)...)
+		util.Erase(rawPass)
+
+		err = isValid(string(pass))
+		if err == nil {
+			return pass
+		} else {
+			util.Erase(pass)
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			//loop and try again
+		}
+	}
+}
+
+/*
+Calculate the coordinates of every site in a file with one password entry.
+*/
+func doBatch(args []string) {
+	fs := newFlagSet("batch")
+	gHashFlags.register(fs)
+	format := fs.String("format", "", "Input and output format: csv or jsonl (default from the file extension)")
+	outPath := fs.String("out", "", "Write the results to this file instead of stdout")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		commandUsage("batch", args)
+	}
+	inPath := fs.Arg(0)
+
+	hf := gHashFlags
+	nWords, typoHints, keyboard, minBits, refuseWeak := hf.nWords, hf.typoHints, hf.keyboard, hf.minBits, hf.refuseWeak
+	cacheFile, cacheTTL := hf.cacheSetting(), hf.cacheTTL
+	layout, opt := hf.settings()
+	ensureSelfTest()
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(inPath)) {
+		case ".jsonl", ".json":
+			*format = "jsonl"
+		default:
+			*format = "csv"
+		}
+	} else if *format != "csv" && *format != "jsonl" {
+		log.Fatalf("unknown format \"%s\"", *format)
+	}
+
+	in, err := os.Open(inPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sites, err := readBatchSites(in, *format)
+	in.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(sites) == 0 {
+		log.Fatal("no sites in " + inPath)
+	}
+
+	out := os.Stdout
+	if *outPath != "" {
+		out, err = os.OpenFile(*outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer out.Close()
+	}
+
+	coordPass := securePromptBytes("Coordinate Password", func(s string) error {
+		return checkCoordPass(s, typoHints, keyboard, minBits, refuseWeak)
+	})
+
+	if pass, _ := type1.SplitCheckword(string(coordPass)); !type1.IsStrongCoordPass(pass, minBits) {
+		fmt.Fprintf(os.Stderr, "Warning: weak coordinate password! %s\n", strengthMessage(pass, minBits))
+	}
+
+	var cache *sitecache.Cache
+	if cacheFile != "" {
+		cache = openCache(cacheFile, coordPass, opt, warnStderr)
+		if cache != nil {
+			defer cache.Erase()
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Calculating %d sites...\n", len(sites))
+	results := calcSiteHashesCached(cache, cacheTTL, coordPass, sites, opt, warnStderr)
+	util.Erase(coordPass)
+
+	nFailed := 0
+	cw := csv.NewWriter(out)
+	enc := json.NewEncoder(out)
+	for i, res := range results {
+		rec := batchRecord{Site: sites[i].Sitename, Personalization: sites[i].Personalization}
+
+		err = res.Err
+		if err == nil {
+			rec.Coordinates, err = type1.GetWordCoordinatesWithOptions(res.Hash, nWords, layout, opt)
+		}
+		if err != nil {
+			rec.Error = err.Error()
+			nFailed++
+		}
+
+		if *format == "jsonl" {
+			err = enc.Encode(&rec)
+		} else {
+			err = cw.Write([]string{rec.Site, rec.Personalization, strings.Join(rec.Coordinates, " "), rec.Error})
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cw.Flush()
+	if err = cw.Error(); err != nil {
+		log.Fatal(err)
+	}
+
+	if nFailed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d sites failed\n", nFailed, len(sites))
+		os.Exit(exitFailure)
+	}
+}
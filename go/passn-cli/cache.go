@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cruxic/passillion/go/sitecache"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"log"
+	"os"
+	"time"
+)
+
+//The -cachefile flag or else the default cache location.
+func cachePath(path string) string {
+	if path != "" {
+		return path
+	}
+
+	path, err := sitecache.DefaultPath()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return path
+}
+
+/*
+Reports a problem which does not stop the calculation.  The line based
+commands print it (warnStderr); the tui shows it on its own screen.
+*/
+type warnFunc func(msg string)
+
+func warnStderr(msg string) {
+	fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+}
+
+/*
+Open the site hash cache.  The cache is only an optimization so problems
+are reported as warnings and nil is returned.
+*/
+func openCache(path string, password []byte, opt type1.Options, warn warnFunc) *sitecache.Cache {
+	cache, err := sitecache.Open(path, password, opt, util.NewCryptoRandByteSource())
+	if err != nil {
+		warn("not using the cache: " + err.Error())
+		return nil
+	}
+	return cache
+}
+
+//Add entries and save, warning about problems.
+func updateCache(cache *sitecache.Cache, sites []type1.SiteInput, results []type1.SiteResult, ttl time.Duration, warn warnFunc) {
+	for i, res := range results {
+		if res.Err == nil {
+			if err := cache.Put(sites[i].Sitename, sites[i].Personalization, res.Hash, ttl); err != nil {
+				warn("not caching: " + err.Error())
+				return
+			}
+		}
+	}
+
+	if err := cache.Save(); err != nil {
+		warn("could not save the cache: " + err.Error())
+	}
+}
+
+/*
+Site hashes for all sites, using the cache (if not nil) for those already
+calculated.  New results are added to the cache.
+*/
+func calcSiteHashesCached(cache *sitecache.Cache, ttl time.Duration, password []byte, sites []type1.SiteInput, opt type1.Options, warn warnFunc) []type1.SiteResult {
+	results := make([]type1.SiteResult, len(sites))
+
+	var missing []type1.SiteInput
+	var missingIndex []int
+	for i, site := range sites {
+		if cache != nil {
+			if hash, found := cache.Get(site.Sitename, site.Personalization); found {
+				results[i].Hash = hash
+				continue
+			}
+		}
+		missing = append(missing, site)
+		missingIndex = append(missingIndex, i)
+	}
+
+	if len(missing) == 0 {
+		return results
+	}
+
+	calculated := type1.CalcSiteHashes(password, missing, opt)
+	for j, res := range calculated {
+		results[missingIndex[j]] = res
+	}
+
+	if cache != nil {
+		updateCache(cache, missing, calculated, ttl, warn)
+	}
+
+	return results
+}
+
+/*
+Manage the site hash cache.
+*/
+func doCache(args []string) {
+	if len(args) == 0 || args[0] != "clear" {
+		commandUsage("cache", args)
+	}
+
+	fs := newFlagSet("cache clear")
+	path := fs.String("path", "", "Cache file (default $XDG_CACHE_HOME/passillion/sitehashes.json)")
+	fs.Parse(args[1:])
+
+	file := cachePath(*path)
+	if err := sitecache.Clear(file); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Cleared %s\n", file)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cruxic/passillion/go/mnemonic"
+	"github.com/cruxic/passillion/go/type1"
+	"log"
+	"os"
+	"strings"
+)
+
+//Print one quadrant of the card as plain text.
+func printQuadrant(layout *type1.WordLayout, quad int) {
+	n := layout.Layout.ColsPerQuad
+	letters := layout.Layout.Letters[quad*n:quad*n+n]
+	header := ""
+	for i := 0; i < n; i++ {
+		header += fmt.Sprintf("    %-8c", letters[i])
+	}
+	fmt.Println(strings.TrimRight(header, " "))
+
+	for _, row := range layout.GetQuadrantRows(quad) {
+		var cells []string
+		for _, cell := range row {
+			if cell.NumInQuad == 0 {
+				cells = append(cells, strings.Repeat(" ", 12))
+			} else {
+				cells = append(cells, fmt.Sprintf("%3d %-8s", cell.NumInQuad, cell.Word))
+			}
+		}
+		fmt.Println(strings.TrimRight(strings.Join(cells, ""), " "))
+	}
+}
+
+/*
+Print a card generated from a recovery seed.  Without -seed a new seed
+is chosen.  The same seed gives a different card with a different -layout.
+Keep the seed in a sealed envelope: it regenerates the exact same card if
+the original is lost or damaged.
+*/
+func doCard(args []string) {
+	fs := newFlagSet("card")
+	seedHex := fs.String("seed", "", "Recovery seed (32 hex digits) of an existing card")
+	layoutName := fs.String("layout", "standard", "Card layout: standard (256 words), 512 or 1024")
+	fs.Parse(args)
+
+	cardLayout, err := type1.GetLayout(*layoutName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var seed []byte
+	if *seedHex == "" {
+		seed, err = type1.NewCardSeed()
+	} else {
+		seed, err = type1.ParseCardSeed(*seedHex)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	layout, err := type1.CardFromSeed(seed, cardLayout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Card %s\n\n", layout.Fingerprint())
+
+	for quad := 0; quad < cardLayout.NumQuads(); quad++ {
+		printQuadrant(layout, quad)
+		fmt.Println()
+	}
+
+	useWith := "-card " + layout.Fingerprint()
+	if cardLayout != type1.StandardLayout {
+		useWith = "-layout " + cardLayout.Name + " " + useWith
+	}
+	fmt.Printf("Fingerprint: %s (use with `passn coords %s`)\n", layout.Fingerprint(), useWith)
+	fmt.Printf("Recovery seed: %s\n", type1.FormatCardSeed(seed))
+	if words, err := mnemonic.Encode(seed); err == nil {
+		fmt.Printf("As words: %s\n", strings.Join(words, " "))
+		fmt.Println("(convert back with: passn mnemonic decode)")
+	}
+	if *seedHex == "" {
+		fmt.Fprintln(os.Stderr, "Write the recovery seed down and seal it in an envelope.  Anyone who has it can recreate your card.")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//Exit codes.  log.Fatal exits with exitFailure.
+const (
+	exitOK = 0
+	exitFailure = 1
+	exitUsage = 2
+	exitSelfTest = 3
+)
+
+const exitCodeHelp = `Exit codes:
+  0  success
+  1  failure (eg a file could not be read, or a batch site failed)
+  2  usage error: unknown command, bad flag or missing argument
+  3  the self-test failed: this build or machine gives wrong results`
+
+type command struct {
+	name string
+	usage string  //what follows "passn <name>"
+	summary string
+	run func(args []string)
+}
+
+var gCommands []command
+
+//Assigned in init() because doHelp refers to gCommands.
+func init() {
+	gCommands = []command{
+		{"coords", "[coordinate flags] [-site name] [-pers text] [-qr] [-qrcard]", "Calculate the word coordinates of a site (alias: passn -1)", doCoords},
+		{"checkword", "", "Show the checkword of a password (alias: passn -checkword)", doCheckword},
+		{"batch", "[coordinate flags] [-format csv|jsonl] [-out file] <sites file>", "Calculate the coordinates of many sites with one password entry", doBatch},
+		{"tui", "[coordinate flags] [-clear duration] [-history] [-historyfile file]", "Full-screen terminal UI with live checkword feedback", doTUI},
+		{"card", "[-seed hex] [-layout name]", "Generate a word card, or reproduce one from its recovery seed", doCard},
+		{"genpass", "[-n words] [-list name]", "Generate a random coordinate password", doGenpass},
+		{"explain", "[flags]", "Estimate the entropy and cracking cost of the coordinates", doExplain},
+		{"split", "[-m M] [-n N] [-raw]", "Split a secret into Shamir shares", doSplit},
+		{"combine", "[-raw]", "Recover a secret from Shamir shares", doCombine},
+		{"mnemonic", "encode [hex] | decode [words...]", "Convert a hex secret to mnemonic words and back", doMnemonic},
+		{"keyfile", "new <path>", "Create a random keyfile", doKeyfile},
+		{"pepper", "init|export|import [-path file] [-force]", "Manage this machine's pepper", doPepper},
+		{"cache", "clear [-path file]", "Delete the site hash cache", doCache},
+		{"selftest", "", "Run the known-answer self-test", doSelftest},
+		{"web", "[-addr host:port]", "Serve the web calculator", doWeb},
+		{"wordlist", "build|check [flags]", "Curate and analyze card word lists", doWordlist},
+		{"help", "[command]", "Show help for passn or a command", doHelp},
+	}
+}
+
+func findCommand(name string) *command {
+	for i := range gCommands {
+		if gCommands[i].name == name {
+			return &gCommands[i]
+		}
+	}
+	return nil
+}
+
+func isHelpArg(s string) bool {
+	return s == "-h" || s == "-help" || s == "--help"
+}
+
+func printCommandHelp(cmd *command) {
+	fmt.Fprintf(os.Stderr, "Usage: passn [global flags] %s %s\n\n%s.\n", cmd.name, cmd.usage, cmd.summary)
+	if strings.HasPrefix(cmd.usage, "[coordinate flags]") {
+		fmt.Fprintln(os.Stderr, "\nThe coordinate flags may also be given before the command.")
+	}
+}
+
+/*
+A FlagSet whose -h shows the help of the command.  name may include an
+action, eg "pepper init".
+*/
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		printCommandHelp(findCommand(strings.Fields(name)[0]))
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+/*
+Show the help of a command and exit: successfully if it was asked for with
+-h, otherwise as a usage error.
+*/
+func commandUsage(name string, args []string) {
+	printCommandHelp(findCommand(name))
+	if len(args) > 0 && isHelpArg(args[0]) {
+		os.Exit(exitOK)
+	}
+	os.Exit(exitUsage)
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: passn [global flags] <command> [arguments]\n\nCommands:")
+	for _, cmd := range gCommands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun `passn help <command>` for the arguments and flags of a command.\n\nGlobal flags:")
+	flag.PrintDefaults()
+	fmt.Fprintln(os.Stderr, "\n" + exitCodeHelp)
+}
+
+func doHelp(args []string) {
+	if len(args) == 0 {
+		printUsage()
+		return
+	}
+
+	cmd := findCommand(args[0])
+	if len(args) != 1 || cmd == nil || cmd.name == "help" {
+		commandUsage("help", nil)
+	}
+
+	//every command shows its full help for -h
+	cmd.run([]string{"-h"})
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//Global flags which cannot be set in the config file.
+var gNotConfigurable = map[string]bool{"config": true, "1": true, "checkword": true}
+
+//$XDG_CONFIG_HOME/passillion/passn.conf or "" if there is no config dir.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "passillion", "passn.conf")
+}
+
+/*
+Set the global flags from a config file of "name = value" lines, eg
+
+	# always use my keyfile
+	keyfile = /media/usb/passillion.key
+	typohints = true
+
+Flags given on the command line take precedence.  A missing file is
+only an error if it was named explicitly.
+*/
+func applyConfig(path string, explicit bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	onCommandLine := make(map[string]bool)
+	flag.Visit(func(fl *flag.Flag) {
+		onCommandLine[fl.Name] = true
+	})
+
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		i := strings.Index(line, "=")
+		if i < 0 {
+			return fmt.Errorf("%s:%d: expected name = value", path, lineNum)
+		}
+		name := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+
+		if flag.Lookup(name) == nil || gNotConfigurable[name] {
+			return fmt.Errorf("%s:%d: unknown setting \"%s\"", path, lineNum, name)
+		}
+		if onCommandLine[name] {
+			continue
+		}
+		if err = flag.Set(name, value); err != nil {
+			return fmt.Errorf("%s:%d: %s", path, lineNum, err.Error())
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"flag"
+	"github.com/cruxic/passillion/go/pepper"
+	"github.com/cruxic/passillion/go/sitecache"
+	"github.com/cruxic/passillion/go/type1"
+	"log"
+	"time"
+)
+
+/*
+Flags which affect how coordinates are calculated.  They are accepted both
+before the command (`passn -keyfile k coords`, as in older versions) and
+after it (`passn coords -keyfile k`).  Both FlagSets share these fields.
+*/
+type hashFlags struct {
+	nWords int
+	typoHints bool
+	keyboard string
+	minBits float64
+	refuseWeak bool
+	layoutName string
+	distinct bool
+	keyfile string
+	pepper bool
+	pepperFile string
+	cardId string
+	mixCard bool
+	cache bool
+	cacheFile string
+	cacheTTL time.Duration
+
+	//coords only
+	site string
+	pers string
+	qr bool
+	qrCard bool
+}
+
+var gHashFlags = &hashFlags{
+	nWords: 4,
+	minBits: type1.MinCoordPassBits,
+	layoutName: "standard",
+	cacheTTL: sitecache.DefaultTTL,
+}
+
+/*
+Define the flags in fs.  The current values are the defaults so a second
+FlagSet keeps what the global flags or the config file already set.
+*/
+func (self *hashFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&self.nWords, "n", self.nWords, "Output a different number of word coordinates")
+	fs.BoolVar(&self.typoHints, "typohints", self.typoHints, "When the checkword is wrong, guess what kind of typo was made.")
+	fs.StringVar(&self.keyboard, "keyboard", self.keyboard, "Keyboard layout for -typohints: qwerty, azerty or dvorak (default all)")
+	fs.Float64Var(&self.minBits, "minbits", self.minBits, "Warn if the coordinate password has less estimated entropy (bits)")
+	fs.BoolVar(&self.refuseWeak, "refuseweak", self.refuseWeak, "Refuse coordinate passwords weaker than -minbits instead of warning")
+	fs.StringVar(&self.layoutName, "layout", self.layoutName, "Card layout: standard (256 words), 512 or 1024")
+	fs.BoolVar(&self.distinct, "distinct", self.distinct, "Never repeat a word in the coordinates (changes coordinates which had a repeat)")
+	fs.StringVar(&self.keyfile, "keyfile", self.keyfile, "Combine the password with this keyfile (see \"passn keyfile new\")")
+	fs.BoolVar(&self.pepper, "pepper", self.pepper, "Mix this machine's pepper into the hash (see \"passn pepper init\")")
+	fs.StringVar(&self.pepperFile, "pepperfile", self.pepperFile, "Pepper file for -pepper (default $XDG_DATA_HOME/passillion/pepper)")
+	fs.StringVar(&self.cardId, "card", self.cardId, "Card ID (eg its fingerprint) to display with the coordinates")
+	fs.BoolVar(&self.mixCard, "mixcard", self.mixCard, "Mix the -card ID into the hash so each card gives different coordinates")
+	fs.BoolVar(&self.cache, "cache", self.cache, "Cache site hashes encrypted under the password so repeat lookups are instant (trusted machines only)")
+	fs.StringVar(&self.cacheFile, "cachefile", self.cacheFile, "Cache file for -cache (default $XDG_CACHE_HOME/passillion/sitehashes.json)")
+	fs.DurationVar(&self.cacheTTL, "cachettl", self.cacheTTL, "How long -cache keeps a site hash")
+}
+
+//The flags which only make sense for a single site shown on screen.
+func (self *hashFlags) registerSite(fs *flag.FlagSet) {
+	fs.StringVar(&self.site, "site", self.site, "Sitename (instead of asking for it, eg with -pinentry and no terminal)")
+	fs.StringVar(&self.pers, "pers", self.pers, "Personalization: revision number, user name, etc (not asked for when -site is given)")
+	fs.BoolVar(&self.qr, "qr", self.qr, "Also show the coordinates as a QR code")
+	fs.BoolVar(&self.qrCard, "qrcard", self.qrCard, "Include the -card ID in the QR code")
+}
+
+//Layout and Options for coords, batch and tui
+func (self *hashFlags) settings() (*type1.Layout, type1.Options) {
+	if self.mixCard && self.cardId == "" {
+		log.Fatal("-mixcard requires -card")
+	}
+	layout, err := type1.GetLayout(self.layoutName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opt := type1.Options{
+		CardId: self.cardId,
+		MixCardId: self.mixCard,
+		DistinctWords: self.distinct,
+	}
+	if self.pepper || self.pepperFile != "" {
+		opt.Pepper, err = pepper.Load(pepperPath(self.pepperFile))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if self.keyfile != "" {
+		opt.KeyfileDigest, err = type1.ReadKeyfile(self.keyfile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	return layout, opt
+}
+
+//"" when the cache is disabled
+func (self *hashFlags) cacheSetting() string {
+	if !self.cache && self.cacheFile == "" {
+		return ""
+	}
+	return cachePath(self.cacheFile)
+}
+
+/*
+Prompt for a site and the coordinate password and print the coordinates.
+*/
+func doCoords(args []string) {
+	fs := newFlagSet("coords")
+	gHashFlags.register(fs)
+	gHashFlags.registerSite(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		commandUsage("coords", args)
+	}
+
+	hf := gHashFlags
+	layout, opt := hf.settings()
+	ensureSelfTest()
+
+	qrPrefix := ""
+	if hf.qrCard {
+		if hf.cardId == "" {
+			log.Fatal("-qrcard requires -card")
+		}
+		qrPrefix = hf.cardId
+	}
+	doType1(hf.site, hf.pers, hf.nWords, hf.typoHints, hf.keyboard, hf.minBits, hf.refuseWeak, layout, opt, hf.qr, qrPrefix, hf.cacheSetting(), hf.cacheTTL)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+//Set by runPassn: run main() instead of the tests.
+const envRunMain = "PASSN_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(envRunMain) == "1" {
+		os.Args = append([]string{"passn"}, strings.Fields(os.Getenv(envRunMain + "_ARGS"))...)
+		main()
+		os.Exit(exitOK)
+	}
+	os.Exit(m.Run())
+}
+
+/*
+Run passn (this test binary) with stdin from /dev/null, as when started
+from a window manager keybinding.  Returns stdout, stderr and the exit code.
+*/
+func runPassn(t *testing.T, home string, args ...string) (string, string, int) {
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(),
+		envRunMain + "=1",
+		envRunMain + "_ARGS=" + strings.Join(args, " "),
+		"HOME=" + home,
+		"XDG_CONFIG_HOME=" + filepath.Join(home, "config"),
+		"XDG_CACHE_HOME=" + filepath.Join(home, "cache"),
+		"XDG_DATA_HOME=" + filepath.Join(home, "data"))
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	code := exitOK
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		code = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return stdout.String(), stderr.String(), code
+}
+
+/*
+A stand-in pinentry which logs every command to dir/log and answers each
+GETPIN with the next of pins.  See fakePinentry in the pinentry package.
+*/
+func fakePinentry(t *testing.T, dir string, pins ...string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs /bin/sh")
+	}
+
+	pinsPath := filepath.Join(dir, "pins")
+	if err := ioutil.WriteFile(pinsPath, []byte(strings.Join(pins, "\n") + "\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	script := fmt.Sprintf(`#!/bin/sh
+n=0
+echo "OK Pleased to meet you"
+while read -r line; do
+	echo "$line" >> '%s'
+	case "$line" in
+	GETPIN)
+		n=$((n + 1))
+		pin=$(sed -n "${n}p" '%s')
+		if [ -z "$pin" ]; then
+			echo "ERR 83886179 Operation cancelled <Pinentry>"
+		else
+			echo "D $pin"
+			echo "OK"
+		fi
+		;;
+	BYE)
+		echo "OK closing connection"
+		exit 0
+		;;
+	*)
+		echo "OK"
+		;;
+	esac
+done
+`, filepath.Join(dir, "log"), pinsPath)
+
+	path := filepath.Join(dir, "fake-pinentry")
+	if err := ioutil.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_CoordsWithoutTerminal(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "passn")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	//a wrong checkword first
+	prog := fakePinentry(t, dir, "Super Secretcat", "Super Secretdog")
+
+	stdout, stderr, code := runPassn(t, dir, "-pinentry", prog, "-output", "json", "coords", "-site", "example.com", "-pers", "2")
+	assert.Equal(exitOK, code, stderr)
+	assert.NotContains(stderr, "Sitename")
+
+	var rec batchRecord
+	assert.NoError(json.Unmarshal([]byte(stdout), &rec))
+	assert.Equal("example.com", rec.Site)
+	assert.Equal("2", rec.Personalization)
+	assert.Equal(4, len(rec.Coordinates))
+
+	log, _ := ioutil.ReadFile(filepath.Join(dir, "log"))
+	assert.Equal(2, strings.Count(string(log), "GETPIN\n"))
+	assert.Contains(string(log), "SETERROR ")
+
+	//the same coordinates before the command (older usage)
+	stdout2, stderr, code := runPassn(t, dir, "-pinentry", prog, "-output", "json", "-site", "example.com", "-pers", "2", "coords")
+	assert.Equal(exitOK, code, stderr)
+	assert.Equal(stdout, stdout2)
+
+	//without -site it still needs stdin
+	_, stderr, code = runPassn(t, dir, "-pinentry", prog, "coords")
+	assert.Equal(exitFailure, code)
+	assert.Contains(stderr, "Sitename: error reading stdin")
+}
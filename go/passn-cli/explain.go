@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cruxic/passillion/go/type1"
+	"log"
+)
+
+//Format a duration given in years, eg "3.2 hours" or "1.5e+12 years".
+func humanYears(years float64) string {
+	seconds := years * 365.25 * 24 * 3600
+	switch {
+	case seconds < 60:
+		return fmt.Sprintf("%.1f seconds", seconds)
+	case seconds < 3600:
+		return fmt.Sprintf("%.1f minutes", seconds / 60)
+	case seconds < 24 * 3600:
+		return fmt.Sprintf("%.1f hours", seconds / 3600)
+	case years < 1:
+		return fmt.Sprintf("%.1f days", seconds / (24 * 3600))
+	case years < 1e6:
+		return fmt.Sprintf("%.1f years", years)
+	default:
+		return fmt.Sprintf("%.1e years", years)
+	}
+}
+
+func humanDollars(dollars float64) string {
+	if dollars < 0.01 {
+		return "under $0.01"
+	}
+	if dollars < 1e6 {
+		return fmt.Sprintf("$%.2f", dollars)
+	}
+	return fmt.Sprintf("$%.1e", dollars)
+}
+
+/*
+Print the entropy of the site password and coordinate password and the
+cost of cracking them.
+*/
+func doExplain(args []string) {
+	def := type1.DefaultAttackAssumptions()
+
+	fs := newFlagSet("explain")
+	gHashFlags.register(fs)
+	coordBits := fs.Float64("coordbits", -1, "Entropy of the coordinate password in bits (default: prompt for the password and estimate it)")
+	bcryptRate := fs.Float64("bcryptrate", def.BcryptCost5PerSec, "Attacker bcrypt hashes per second per GPU at cost 5")
+	fastRate := fs.Float64("fastrate", def.FastHashPerSec, "Attacker fast hash guesses per second per GPU")
+	gpuHour := fs.Float64("gpuhour", def.DollarsPerGPUHour, "Dollars per GPU hour")
+	fs.Parse(args)
+
+	hf := gHashFlags
+	layout, opt := hf.settings()
+
+	bits := *coordBits
+	if bits < 0 {
+		coordPass := securePrompt("Coordinate Password", func(s string) error {
+			pass, checkword := type1.SplitCheckword(s)
+			if !type1.IsCorrectCheckword(pass, checkword) {
+				return wrongCheckword(s, hf.typoHints, hf.keyboard)
+			}
+			return nil
+		})
+		pass, _ := type1.SplitCheckword(coordPass)
+		bits = type1.EstimateCoordPassStrength(pass).Bits
+	}
+
+	ex, err := type1.Explain(bits, hf.nWords, layout, opt, type1.AttackAssumptions{
+		BcryptCost5PerSec: *bcryptRate,
+		FastHashPerSec: *fastRate,
+		DollarsPerGPUHour: *gpuHour,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Card: %s layout, %d words (%d bits per word)\n", layout.Name, layout.NumWords(), layout.BitsPerWord())
+	fmt.Printf("Site password: %d words, %.1f bits", ex.NWords, ex.SitePasswordBits)
+	if ex.DistinctWords {
+		fmt.Print(" (no repeated words)")
+	}
+	fmt.Println()
+	fmt.Println("  Capitalizing the first word and adding a digit adds no entropy.")
+	fmt.Printf("Coordinate password: %.1f bits (estimated)\n", ex.CoordPassBits)
+	fmt.Printf("KDF: %d bcrypt threads of cost %d, about %.0f guesses per second per GPU\n",
+		type1.KDFThreads, type1.KDFCost, ex.KDFGuessesPerSec)
+	if ex.Keyfile {
+		fmt.Println("Keyfile: the card and coordinate password alone cannot make a site password.")
+	}
+	if ex.Pepper {
+		fmt.Println("Pepper: mixed into every site hash; site passwords only work on machines with the pepper.")
+	}
+	fmt.Println()
+
+	for _, sc := range ex.Scenarios {
+		fmt.Printf("Scenario: %s\n", sc.Name)
+		fmt.Printf("  %s\n", sc.Description)
+		fmt.Printf("  Search space: 2^%.1f at %.3g guesses/sec per GPU\n", sc.Bits, sc.GuessesPerSec)
+		fmt.Printf("  Expected: %s on one GPU, %s at %s per GPU hour\n\n",
+			humanYears(sc.GPUYears), humanDollars(sc.Dollars), humanDollars(*gpuHour))
+	}
+
+	if ex.CoordPassBits < hf.minBits {
+		fmt.Printf("Warning: the coordinate password is below the recommended %.0f bits.\n", hf.minBits)
+	}
+}
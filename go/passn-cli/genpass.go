@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"github.com/cruxic/passillion/go/wordlist"
+	"log"
+	"math"
+	"os"
+	"strings"
+)
+
+/*
+Generate a random coordinate password by choosing words uniformly
+from a word list (like Diceware).
+*/
+func doGenpass(args []string) {
+	fs := newFlagSet("genpass")
+	nWords := fs.Int("n", 5, "Number of words")
+	listName := fs.String("list", wordlist.Standard, "Word list: \"standard\", \"aspell4\" or a file with one word per line")
+	fs.Parse(args)
+
+	if *nWords < 1 {
+		log.Fatal("-n must be at least 1")
+	}
+
+	words, err := wordlist.Get(*listName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(words) < 2 {
+		log.Fatal("word list is too short")
+	}
+
+	rng := util.NewCryptoRandByteSource()
+	defer rng.Erase()
+	chosen := make([]string, *nWords)
+	for i := range chosen {
+		k, err := util.UnbiasedInt(rng, len(words))
+		if err != nil {
+			log.Fatal(err)
+		}
+		chosen[i] = words[k]
+	}
+
+	pass := strings.Join(chosen, " ")
+	if len(pass) < type1.MinCoordPassLen {
+		log.Fatalf("Password must be at least %d characters. Use more words.", type1.MinCoordPassLen)
+	}
+
+	//Exact, since every word was chosen uniformly.  The estimate shown when
+	// the password is typed (type1.EstimateCoordPassStrength) cannot know
+	// that: it charges a couple of bits per space and fewer for words which
+	// are also common English words, so it differs by a few bits.
+	bits := float64(*nWords) * math.Log2(float64(len(words)))
+	est := type1.EstimateCoordPassStrength(pass)
+
+	fmt.Printf("%s %s\n\n", pass, type1.CalcCheckword(pass))
+	fmt.Printf("Entropy: %.1f bits (%d words chosen from %d)\n", bits, *nWords, len(words))
+	fmt.Printf("The strength meter, which does not know the words were random, estimates %.1f bits.\n", est.Bits)
+	if bits < type1.MinCoordPassBits || est.Bits < type1.MinCoordPassBits {
+		fmt.Fprintf(os.Stderr, "Warning: less than the recommended %d bits. Use more words.\n", type1.MinCoordPassBits)
+	}
+	fmt.Println("The last word is the checkword. Type it after your password.")
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"log"
+)
+
+/*
+Create a new random keyfile.
+*/
+func doKeyfile(args []string) {
+	if len(args) != 2 || args[0] != "new" {
+		commandUsage("keyfile", args)
+	}
+
+	rng := util.NewCryptoRandByteSource()
+	defer rng.Erase()
+
+	if err := type1.WriteNewKeyfile(args[1], rng); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Created %s\n", args[1])
+	fmt.Println("Use it with `passn coords -keyfile " + args[1] + "`.  Keep a backup: without it your site passwords cannot be recalculated.")
+}
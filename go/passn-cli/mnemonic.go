@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/cruxic/passillion/go/mnemonic"
+	"log"
+	"os"
+	"strings"
+)
+
+//Use the arguments or else read one line from stdin.
+func argsOrStdin(args []string, prompt string) string {
+	if len(args) > 0 {
+		return strings.Join(args, " ")
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && len(line) == 0 {
+		log.Fatal("error reading stdin")
+	}
+	return line
+}
+
+/*
+Convert a hex secret (eg a card recovery seed) to mnemonic words and back.
+*/
+func doMnemonic(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "encode":
+			s := argsOrStdin(args[1:], "Hex secret")
+			s = strings.Join(strings.Fields(s), "")
+			s = strings.Replace(s, "-", "", -1)
+			secret, err := hex.DecodeString(s)
+			if err != nil {
+				log.Fatal("secret must be hexadecimal")
+			}
+
+			words, err := mnemonic.Encode(secret)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(strings.Join(words, " "))
+			return
+		case "decode":
+			words := strings.Fields(argsOrStdin(args[1:], "Words"))
+			secret, err := mnemonic.Decode(words)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(hex.EncodeToString(secret))
+			return
+		}
+	}
+
+	commandUsage("mnemonic", args)
+}
@@ -6,37 +6,86 @@ package main
 import (
 	"log"
 	"flag"
+	"github.com/cruxic/passillion/go/sitecache"
+	"github.com/cruxic/passillion/go/strength"
 	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
 	"golang.org/x/crypto/ssh/terminal"  //for reading password from the console
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"os"
 	"syscall"
+	"time"
 )
 
 
+//-output: "text" or "json"
+var gOutput = "text"
+
 func main() {
 	log.SetFlags(0)  //no timestamp
+	flag.Usage = printUsage
 
-	flagType1 := flag.Bool("1", false, "Use \"Type 1\" algorithm")
-	nWords := flag.Int("n", 4, "Output a different number of word coordinates")
-	flagCheckword := flag.Bool("checkword", false, "Print the 3 letter \"checkword\" for a given password.")
+	configFile := flag.String("config", "", "Read default flag values from this file (default $XDG_CONFIG_HOME/passillion/passn.conf)")
+	flag.StringVar(&gOutput, "output", gOutput, "Output format of coords, checkword and selftest: text or json")
+	algo := flag.String("algo", "type1", "Algorithm.  Only \"type1\" exists so far.")
+	flagType1 := flag.Bool("1", false, "Same as \"passn coords\"")
+	flagCheckword := flag.Bool("checkword", false, "Same as \"passn checkword\"")
+	flag.StringVar(&gPinentry, "pinentry", "", "Ask for passwords with this pinentry program (eg pinentry-gnome3) instead of on the terminal")
+	gHashFlags.register(flag.CommandLine)
+	gHashFlags.registerSite(flag.CommandLine)
 
 	flag.Parse()
 
-	if *flagCheckword {
-		doCheckword()
+	var err error
+	if *configFile != "" {
+		err = applyConfig(*configFile, true)
+	} else if path := defaultConfigPath(); path != "" {
+		err = applyConfig(path, false)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if gOutput != "text" && gOutput != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown -output \"%s\" (expected text or json)\n", gOutput)
+		os.Exit(exitUsage)
+	}
+	if *algo != "type1" {
+		fmt.Fprintf(os.Stderr, "Unknown -algo \"%s\" (only type1 is supported)\n", *algo)
+		os.Exit(exitUsage)
+	}
+
+	if flag.NArg() > 0 {
+		cmd := findCommand(flag.Arg(0))
+		if cmd == nil {
+			fmt.Fprintf(os.Stderr, "Unknown command \"%s\"\n\n", flag.Arg(0))
+			printUsage()
+			os.Exit(exitUsage)
+		}
+		cmd.run(flag.Args()[1:])
+	} else if *flagCheckword {
+		doCheckword(nil)
 	} else if *flagType1 {
-		doType1(*nWords)
+		doCoords(nil)
 	} else {
-		flag.Usage()
+		printUsage()
+		os.Exit(exitUsage)
+	}
+}
+
+//One JSON value on stdout for -output json
+func printJSON(v interface{}) {
+	if err := json.NewEncoder(os.Stdout).Encode(v); err != nil {
+		log.Fatal(err)
 	}
 }
 
 func plainPrompt(reader * bufio.Reader, message string, isValid func(string) error) string {
 	for {
-		fmt.Printf("%s: ", message)
+		fmt.Fprintf(os.Stderr, "%s: ", message)
 		ans, err := reader.ReadString('\n')
 		if err != nil {
 			log.Fatal("error reading stdin")
@@ -52,18 +101,23 @@ func plainPrompt(reader * bufio.Reader, message string, isValid func(string) err
 			//loop and try again
 		}
 	}
-
-	return strings.TrimSpace(message)
 }
 
 func securePrompt(message string, isValid func(string) error) string {
+	if gPinentry != "" {
+		rawPass := pinentryPrompt(message, isValid)
+		pass := string(rawPass)
+		util.Erase(rawPass)
+		return pass
+	}
+
 	for {
-		fmt.Printf("%s: ", message)
+		fmt.Fprintf(os.Stderr, "%s: ", message)
 		rawPass, err := terminal.ReadPassword(int(syscall.Stdin))
 		if err != nil {
 			log.Fatal("error reading stdin")
 		}
-		fmt.Println()
+		fmt.Fprintln(os.Stderr)
 
 		pass := strings.TrimSpace(string(rawPass))
 
@@ -75,55 +129,159 @@ func securePrompt(message string, isValid func(string) error) string {
 			//loop and try again
 		}
 	}
-
-	return strings.TrimSpace(message)
 }
 
-func doType1(nWords int) {
-	reader := bufio.NewReader(os.Stdin)
+/*
+Explain which kind of typo probably caused a checkword mismatch.
+The password itself is never displayed.
+*/
+func typoHintMessage(passwordWithCheckword, keyboard string) string {
+	hints, err := type1.DiagnoseTypo(passwordWithCheckword, keyboard)
+	if err != nil {
+		return err.Error()
+	}
+
+	if len(hints) == 0 {
+		return "No single-keystroke error explains the wrong checkword."
+	}
+
+	lines := make([]string, len(hints))
+	for i, hint := range hints {
+		what := hint.Kind.String()
+		if hint.Keyboard != "" && keyboard == "" {
+			what += fmt.Sprintf(" (on a %s keyboard)", hint.Keyboard)
+		}
 
-	sitename := plainPrompt(reader, "Sitename", func(s string) error {
-		if len(s) == 0 {
-			return fmt.Errorf("Sitename cannot be empty")
+		if hint.Likely {
+			lines[i] = fmt.Sprintf("Hint: %s.", what)
 		} else {
-			return nil
+			lines[i] = fmt.Sprintf("Hint (could be chance): %s.", what)
 		}
-	})
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+/*
+Describe the estimated strength of a coordinate password without revealing it.
+*/
+func strengthMessage(password string, minBits float64) string {
+	return describeStrength(type1.EstimateCoordPassStrength(password), minBits)
+}
+
+func describeStrength(res strength.Result, minBits float64) string {
+	msg := fmt.Sprintf("Estimated %.0f bits, at least %.0f recommended.", res.Bits, minBits)
+	if weak := res.Weaknesses(); len(weak) > 0 {
+		msg += fmt.Sprintf(" Contains: %s.", strings.Join(weak, ", "))
+	}
+	return msg
+}
+
+/*
+Validate a coordinate password (with checkword) as typed at the prompt.
+*/
+func checkCoordPass(s string, typoHints bool, keyboard string, minBits float64, refuseWeak bool) error {
+	if len(s) < type1.MinCoordPassLen {
+		return fmt.Errorf("Password must be at least %d characters", type1.MinCoordPassLen)
+	}
 
-	personalization := plainPrompt(reader, "Revsion number, user name, etc (optional)", func(s string) error {
+	//Verify checkword
+	pass, checkword := type1.SplitCheckword(s)
+	if type1.IsCorrectCheckword(pass, checkword) {
+		if refuseWeak && !type1.IsStrongCoordPass(pass, minBits) {
+			return fmt.Errorf("Password is too weak. %s", strengthMessage(pass, minBits))
+		}
+
+		//Good!
 		return nil
-	})
+	}
+	return wrongCheckword(s, typoHints, keyboard)
+}
 
-	coordPass := securePrompt("Coordinate Password", func(s string) error {
-		if len(s) < type1.MinCoordPassLen {
-			return fmt.Errorf("Password must be at least %d characters", type1.MinCoordPassLen)
-		} else {
-			//Verify checkword
-			pass, checkword := type1.SplitCheckword(s)
-			if type1.IsCorrectCheckword(pass, checkword) {
-				//Good!
-				return nil
+/*
+The error for a wrong checkword.  passwordWithCheckword is only used for
+-typohints.
+*/
+func wrongCheckword(passwordWithCheckword string, typoHints bool, keyboard string) error {
+	if typoHints {
+		return fmt.Errorf("Wrong checkword.\n%s", typoHintMessage(passwordWithCheckword, keyboard))
+	}
+	return fmt.Errorf("Typo or missing checkword? Use `passn checkword` if you forgot your checkword.")
+}
+
+/*
+Print the coordinates of one site.  The sitename and personalization are
+asked for on stdin unless sitename is given.
+*/
+func doType1(sitename, personalization string, nWords int, typoHints bool, keyboard string, minBits float64, refuseWeak bool, layout *type1.Layout, opt type1.Options, showQR bool, qrPrefix string, cacheFile string, cacheTTL time.Duration) {
+	if sitename == "" {
+		reader := bufio.NewReader(os.Stdin)
+
+		sitename = plainPrompt(reader, "Sitename", func(s string) error {
+			if len(s) == 0 {
+				return fmt.Errorf("Sitename cannot be empty")
 			} else {
-				return fmt.Errorf("Typo or missing checkword? Use `passn -checkword` if you forgot your checkword.")
+				return nil
 			}
+		})
+
+		if personalization == "" {
+			personalization = plainPrompt(reader, "Revsion number, user name, etc (optional)", func(s string) error {
+				return nil
+			})
 		}
+	}
+
+	coordPass := securePrompt("Coordinate Password", func(s string) error {
+		return checkCoordPass(s, typoHints, keyboard, minBits, refuseWeak)
 	})
 
-	sitehash, err := type1.CalcSiteHash(coordPass, sitename, personalization)
-	if err != nil {
-		log.Fatal(err)
+	if pass, _ := type1.SplitCheckword(coordPass); !type1.IsStrongCoordPass(pass, minBits) {
+		fmt.Fprintf(os.Stderr, "Warning: weak coordinate password! %s\n", strengthMessage(pass, minBits))
+	}
+
+	var cache *sitecache.Cache
+	passBytes := []byte(coordPass)
+	if cacheFile != "" {
+		cache = openCache(cacheFile, passBytes, opt, warnStderr)
+		if cache != nil {
+			defer cache.Erase()
+		}
+	}
+
+	sites := []type1.SiteInput{{Sitename: sitename, Personalization: personalization}}
+	res := calcSiteHashesCached(cache, cacheTTL, passBytes, sites, opt, warnStderr)[0]
+	util.Erase(passBytes)
+	if res.Err != nil {
+		log.Fatal(res.Err)
 	}
+	sitehash := res.Hash
 
-	coords, err := type1.GetWordCoordinates(sitehash, nWords)
+	coords, err := type1.GetWordCoordinatesWithOptions(sitehash, nWords, layout, opt)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	fmt.Println("Word coordinates:\n")
+	if gOutput == "json" {
+		//same record as `passn batch -format jsonl`
+		printJSON(&batchRecord{Site: sitename, Personalization: personalization, Coordinates: coords})
+		return
+	}
+
+	if opt.CardId != "" {
+		fmt.Printf("Word coordinates for card %s:\n\n", opt.CardId)
+	} else {
+		fmt.Print("Word coordinates:\n\n")
+	}
 	for _, coord := range coords {
 		fmt.Printf("  %s", coord)
 	}
-	fmt.Println("\n")
+	fmt.Print("\n\n")
+
+	if showQR {
+		printQR(qrPrefix, coords)
+	}
+
 	fmt.Println(`Remember:
   1. Beware of Phishing!  Don't log in via email links.
   2. Capitalize the first word.
@@ -131,9 +289,17 @@ func doType1(nWords int) {
   4. No spaces.`)
 }
 
-func doCheckword() {
-	fmt.Println("The \"checkword\" is 3 letter word which you type after your password to detect\n" +
-		"a typo in the preceeding characters. Enter a password now to see the associated\ncheckword.")
+func doCheckword(args []string) {
+	fs := newFlagSet("checkword")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		commandUsage("checkword", args)
+	}
+
+	if gOutput == "text" {
+		fmt.Println("The \"checkword\" is 3 letter word which you type after your password to detect\n" +
+			"a typo in the preceeding characters. Enter a password now to see the associated\ncheckword.")
+	}
 
 	pass := securePrompt("Enter any password", func(s string) error {
 		if len(s) == 0 {
@@ -144,5 +310,9 @@ func doCheckword() {
 	})
 
 	checkword := type1.CalcCheckword(pass)
-	fmt.Printf("Checkword: %s\n", checkword)
+	if gOutput == "json" {
+		printJSON(map[string]string{"checkword": checkword})
+	} else {
+		fmt.Printf("Checkword: %s\n", checkword)
+	}
 }
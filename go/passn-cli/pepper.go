@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/cruxic/passillion/go/pepper"
+	"github.com/cruxic/passillion/go/util"
+	"log"
+	"os"
+)
+
+//The -path flag or else the default pepper location.
+func pepperPath(path string) string {
+	if path != "" {
+		return path
+	}
+
+	path, err := pepper.DefaultPath()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return path
+}
+
+/*
+Manage the machine-local pepper: init, export and import.
+*/
+func doPepper(args []string) {
+	if len(args) == 0 {
+		commandUsage("pepper", args)
+	}
+
+	fs := newFlagSet("pepper " + args[0])
+	path := fs.String("path", "", "Pepper file (default $XDG_DATA_HOME/passillion/pepper)")
+	force := fs.Bool("force", false, "import: replace an existing pepper")
+	fs.Parse(args[1:])
+
+	file := pepperPath(*path)
+
+	switch args[0] {
+	case "init":
+		rng := util.NewCryptoRandByteSource()
+		defer rng.Erase()
+		p, err := pepper.Init(file, rng)
+		if err != nil {
+			log.Fatal(err)
+		}
+		util.Erase(p)
+		fmt.Printf("Created %s\n", file)
+		fmt.Println("Use it with `passn coords -pepper`.  Run `passn pepper export` to back it up or move it to another machine.")
+	case "export":
+		p, err := pepper.Load(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		words, err := pepper.Export(p)
+		util.Erase(p)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(words)
+	case "import":
+		fmt.Print("Pepper words: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && len(line) == 0 {
+			log.Fatal("error reading stdin")
+		}
+
+		p, err := pepper.Parse(line)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer util.Erase(p)
+
+		if err = pepper.Save(file, p, *force); err != nil {
+			if os.IsExist(err) {
+				log.Fatalf("%s already exists (use -force to replace it)", file)
+			}
+			log.Fatal(err)
+		}
+		fmt.Printf("Saved %s\n", file)
+	default:
+		commandUsage("pepper", args)
+	}
+}
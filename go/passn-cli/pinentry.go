@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"github.com/cruxic/passillion/go/pinentry"
+	"github.com/cruxic/passillion/go/util"
+	"log"
+)
+
+//-pinentry: "" to prompt on the terminal
+var gPinentry string
+
+func checkPinentry(err error) {
+	if err == pinentry.ErrCancelled {
+		log.Fatal("Cancelled.")
+	} else if err != nil {
+		log.Fatal(err)
+	}
+}
+
+/*
+Like securePromptBytes but with a pinentry dialog, which works without a
+terminal (eg from a window manager keybinding).  A rejected password (eg a
+wrong checkword) is explained in the dialog when it asks again.
+*/
+func pinentryPrompt(message string, isValid func(string) error) []byte {
+	client, err := pinentry.Open(gPinentry)
+	checkPinentry(err)
+	defer client.Close()
+
+	checkPinentry(client.SetTitle("passn"))
+	checkPinentry(client.SetDesc("Passillion word coordinate calculator"))
+	checkPinentry(client.SetPrompt(message + ":"))
+
+	for {
+		rawPass, err := client.GetPin()
+		checkPinentry(err)
+
+		pass := append([]byte(nil), bytes.TrimSpace(rawPass)...)
+		util.Erase(rawPass)
+
+		err = isValid(string(pass))
+		if err == nil {
+			return pass
+		}
+
+		util.Erase(pass)
+		checkPinentry(client.SetError(err.Error()))
+	}
+}
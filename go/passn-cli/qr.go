@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cruxic/passillion/go/qr"
+	"log"
+	"strings"
+)
+
+/*
+Show the coordinates as a QR code so they can be scanned with a phone.
+A non-empty prefix, such as the card ID, is prepended.
+*/
+func printQR(prefix string, coords []string) {
+	text := strings.Join(coords, " ")
+	if prefix != "" {
+		text = prefix + " " + text
+	}
+
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Print(code.HalfBlocks(false))
+	fmt.Println()
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/cruxic/passillion/go/type1"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+/*
+A file whose existence records that this exact passn binary passed the
+self-test.  It is named after the SHA-256 of the executable so a new or
+modified binary is tested again.  Returns "" if the executable cannot be read.
+*/
+func selfTestMarker() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+
+	f, err := os.Open(exe)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	sha := sha256.New()
+	if _, err = io.Copy(sha, f); err != nil {
+		return ""
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "passillion", "selftest-passed", hex.EncodeToString(sha.Sum(nil)))
+}
+
+func saveSelfTestMarker(marker string) {
+	if marker == "" {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(marker), 0700) == nil {
+		ioutil.WriteFile(marker, nil, 0600)  //best effort: we'll test again next time
+	}
+}
+
+/*
+Run the self-test on the first use of this binary.  Exits on failure so
+that no coordinates are output.
+*/
+func ensureSelfTest() {
+	marker := selfTestMarker()
+	if marker != "" {
+		if _, err := os.Stat(marker); err == nil {
+			return
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "Running the self-test (first use of this passn binary)...")
+	if err := type1.SelfTest(nil); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\nRefusing to calculate coordinates: this build or machine gives wrong results.\n", err.Error())
+		os.Exit(exitSelfTest)
+	}
+
+	saveSelfTestMarker(marker)
+}
+
+/*
+Always run the self-test and report each known-answer test.
+*/
+func doSelftest(args []string) {
+	fs := newFlagSet("selftest")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		commandUsage("selftest", args)
+	}
+
+	//for -output json
+	type testResult struct {
+		Name string `json:"name"`
+		Error string `json:"error,omitempty"`
+	}
+	var results []testResult
+
+	err := type1.SelfTest(func(name string, err error) {
+		res := testResult{Name: name}
+		if err != nil {
+			res.Error = err.Error()
+		}
+		results = append(results, res)
+
+		if gOutput == "json" {
+			return
+		} else if err != nil {
+			fmt.Printf("FAIL %s: %s\n", name, err.Error())
+		} else {
+			fmt.Printf("ok   %s\n", name)
+		}
+	})
+
+	if gOutput == "json" {
+		printJSON(map[string]interface{}{"passed": err == nil, "tests": results})
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(exitSelfTest)
+	}
+
+	saveSelfTestMarker(selfTestMarker())
+	if gOutput == "text" {
+		fmt.Println("Self-test passed.")
+	}
+}
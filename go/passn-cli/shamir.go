@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/shamir"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"log"
+	"os"
+)
+
+/*
+Split a coordinate password (with its checkword) into M-of-N shares.
+*/
+func doSplit(args []string) {
+	fs := newFlagSet("split")
+	m := fs.Int("m", 2, "Number of shares needed to recover the secret")
+	n := fs.Int("n", 3, "Number of shares to create")
+	raw := fs.Bool("raw", false, "Split any secret, not a coordinate password with checkword")
+	fs.Parse(args)
+
+	var secret string
+	if *raw {
+		secret = securePrompt("Secret", func(s string) error {
+			if len(s) == 0 {
+				return errors.New("Cannot be empty.")
+			}
+			return nil
+		})
+	} else {
+		secret = securePrompt("Coordinate Password", func(s string) error {
+			pass, checkword := type1.SplitCheckword(s)
+			if !type1.IsCorrectCheckword(pass, checkword) {
+				return errors.New("Typo or missing checkword? Use `passn checkword` if you forgot your checkword.")
+			}
+			return nil
+		})
+	}
+
+	rng := util.NewCryptoRandByteSource()
+	defer rng.Erase()
+
+	shares, err := shamir.Split([]byte(secret), *m, *n, rng)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Any %d of these %d shares recover the secret (use `passn combine`):\n\n", *m, *n)
+	for _, share := range shares {
+		fmt.Println(share.String())
+		util.Erase(share.Y)
+	}
+}
+
+/*
+Recover a secret from shares typed (or piped) one per line.
+*/
+func doCombine(args []string) {
+	fs := newFlagSet("combine")
+	raw := fs.Bool("raw", false, "The secret is not a coordinate password with checkword")
+	fs.Parse(args)
+
+	reader := bufio.NewReader(os.Stdin)
+
+	var shares []shamir.Share
+	for len(shares) == 0 || len(shares) < shares[0].Threshold {
+		line := plainPrompt(reader, fmt.Sprintf("Share %d", len(shares) + 1), func(s string) error {
+			share, err := shamir.ParseShare(s)
+			if err != nil {
+				return err
+			}
+
+			//a repeated share adds nothing toward the threshold
+			for i, other := range shares {
+				if other.X == share.X {
+					return fmt.Errorf("Share number %d was already entered (as share %d). Enter a different one.", share.X, i + 1)
+				}
+			}
+			return nil
+		})
+
+		share, _ := shamir.ParseShare(line)
+		shares = append(shares, share)
+	}
+
+	secret, err := shamir.Combine(shares)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !*raw {
+		pass, checkword := type1.SplitCheckword(string(secret))
+		if !type1.IsCorrectCheckword(pass, checkword) {
+			log.Fatal("The recovered password has the wrong checkword. Are the shares from the same split?")
+		}
+		fmt.Println("Checkword verified.")
+	}
+
+	fmt.Printf("Secret: %s\n", secret)
+	util.Erase(secret)
+}
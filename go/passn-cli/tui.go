@@ -0,0 +1,509 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/cruxic/mbcrypt/go"
+	"github.com/cruxic/passillion/go/sitecache"
+	"github.com/cruxic/passillion/go/strength"
+	"github.com/cruxic/passillion/go/tui"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"golang.org/x/crypto/ssh/terminal"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ansiClear = "\x1b[H\x1b[2J"
+	ansiAltScreen = "\x1b[?1049h"
+	ansiMainScreen = "\x1b[?1049l"
+	ansiRed = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiBold = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+const (
+	fieldSite = iota
+	fieldPers
+	fieldPass
+)
+
+type tuiApp struct {
+	fields []*tui.Field
+	focus int
+	history *tui.History
+	message string
+	width int
+
+	//Enter was pressed once despite the weak password warning
+	weakAccepted bool
+
+	nWords int
+	typoHints bool
+	keyboard string
+	minBits float64
+	refuseWeak bool
+	layout *type1.Layout
+	opt type1.Options
+	cacheFile string
+	cacheTTL time.Duration
+}
+
+//Keys from stdin, one slice per read
+func readKeys(keys chan<- []tui.Key) {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			close(keys)
+			return
+		}
+		keys <- tui.ParseKeys(buf[:n])
+	}
+}
+
+//Draw lines starting at the top left.  Raw mode needs \r\n.
+func drawScreen(lines []string) {
+	fmt.Print(ansiClear + strings.Join(lines, "\r\n"))
+}
+
+func (self *tuiApp) suggestions() []string {
+	if self.history == nil || self.focus != fieldSite {
+		return nil
+	}
+
+	var res []string
+	for _, site := range self.history.Suggest(self.fields[fieldSite].Text(), 5) {
+		if site != self.fields[fieldSite].Text() {
+			res = append(res, site)
+		}
+	}
+	return res
+}
+
+//Length of the UTF-8 encoding, as len() of the string would give
+func utf8Len(runes []rune) int {
+	n := 0
+	for _, r := range runes {
+		n += utf8.RuneLen(r)
+	}
+	return n
+}
+
+/*
+Live status of the password field, like onPasswordChange() in calc.ts.  The
+password is checked from the field's runes: a string copy could not be
+erased and one would be made on every keystroke.
+*/
+func (self *tuiApp) checkwordStatus() string {
+	s := self.fields[fieldPass].Runes()
+	if len(s) == 0 {
+		return ""
+	} else if utf8Len(s) < type1.MinCoordPassLen {
+		return ansiYellow + "too short" + ansiReset
+	}
+
+	pass, checkword := type1.SplitCheckwordRunes(s)
+	if !type1.IsCorrectCheckwordRunes(pass, checkword) {
+		return ansiRed + "no valid checkword" + ansiReset
+	} else if _, weak := self.strength(pass); weak {
+		return ansiYellow + "checkword OK, weak password" + ansiReset
+	}
+	return ansiGreen + "checkword OK" + ansiReset
+}
+
+//Estimated strength of the password (without checkword) and whether it is below -minbits
+func (self *tuiApp) strength(pass []rune) (strength.Result, bool) {
+	res := type1.EstimateCoordPassStrengthRunes(pass)
+	return res, utf8Len(pass) < type1.MinCoordPassLen || res.Bits < self.minBits
+}
+
+func (self *tuiApp) drawForm() {
+	title := "Passillion Type 1"
+	if self.opt.CardId != "" {
+		title += " - card " + self.opt.CardId
+	}
+
+	lines := []string{"", "  " + ansiBold + title + ansiReset, ""}
+	const labelWidth = 18
+	firstFieldRow := len(lines) + 1  //1-based
+
+	for i, field := range self.fields {
+		line := fmt.Sprintf("  %-*s%s", labelWidth, field.Label + ":", field.Display())
+		if i == fieldPass {
+			line += "   " + self.checkwordStatus()
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "")
+	if sugg := self.suggestions(); len(sugg) > 0 {
+		line := "  Suggestions: " + strings.Join(sugg, "  ")
+		if self.width > 0 && len(line) > self.width {
+			line = line[:self.width]
+		}
+		lines = append(lines, line)
+	} else {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, "")
+	if self.message != "" {
+		for _, msg := range strings.Split(self.message, "\n") {
+			lines = append(lines, "  " + ansiRed + msg + ansiReset)
+		}
+	}
+	lines = append(lines, "", "  Tab: next field or complete suggestion   Enter: calculate   Ctrl+U: clear   Esc: quit")
+
+	drawScreen(lines)
+
+	//put the cursor in the focused field
+	field := self.fields[self.focus]
+	fmt.Printf("\x1b[%d;%dH", firstFieldRow + self.focus, 3 + labelWidth + field.Cursor())
+}
+
+//Append a line to message
+func (self *tuiApp) addMessage(msg string) {
+	if self.message != "" {
+		self.message += "\n"
+	}
+	self.message += msg
+}
+
+func (self *tuiApp) nextField() {
+	if self.focus == fieldSite {
+		if sugg := self.suggestions(); len(sugg) > 0 && strings.HasPrefix(sugg[0], self.fields[fieldSite].Text()) {
+			self.fields[fieldSite].SetText(sugg[0])
+			return
+		}
+	}
+	self.focus = (self.focus + 1) % len(self.fields)
+}
+
+/*
+Edit the fields until the user submits a valid form (true) or quits (false).
+*/
+func (self *tuiApp) runForm(keys <-chan []tui.Key) bool {
+	for {
+		self.drawForm()
+
+		batch, ok := <-keys
+		if !ok {
+			return false
+		}
+
+		for _, key := range batch {
+			if self.fields[self.focus].HandleKey(key) {
+				self.message = ""
+				self.weakAccepted = false
+				continue
+			}
+
+			switch key.Code {
+			case tui.KeyEsc, tui.KeyCtrlC:
+				return false
+			case tui.KeyTab, tui.KeyDown:
+				self.nextField()
+			case tui.KeyBacktab, tui.KeyUp:
+				self.focus = (self.focus + len(self.fields) - 1) % len(self.fields)
+			case tui.KeyEnter:
+				if self.focus != fieldPass {
+					self.focus++
+				} else if err := self.validate(); err != nil {
+					self.message = err.Error()
+				} else {
+					return true
+				}
+			}
+		}
+	}
+}
+
+func (self *tuiApp) validate() error {
+	if strings.TrimSpace(self.fields[fieldSite].Text()) == "" {
+		self.focus = fieldSite
+		return fmt.Errorf("Sitename cannot be empty")
+	}
+
+	//like checkCoordPass but from the runes (see checkwordStatus)
+	pass, checkword := type1.SplitCheckwordRunes(self.fields[fieldPass].Runes())
+	if utf8Len(pass) + utf8Len(checkword) < type1.MinCoordPassLen {
+		return fmt.Errorf("Password must be at least %d characters", type1.MinCoordPassLen)
+	}
+
+	if !type1.IsCorrectCheckwordRunes(pass, checkword) {
+		entered := ""
+		if self.typoHints {
+			//DiagnoseTypo needs a string (and tries many variations of it)
+			entered = string(self.fields[fieldPass].Runes())
+		}
+		return wrongCheckword(entered, self.typoHints, self.keyboard)
+	}
+
+	//the same warning as passn coords, but before anything is calculated
+	if res, weak := self.strength(pass); weak {
+		if self.refuseWeak {
+			return fmt.Errorf("Password is too weak. %s", describeStrength(res, self.minBits))
+		} else if !self.weakAccepted {
+			self.weakAccepted = true
+			return fmt.Errorf("Warning: weak coordinate password! %s\nPress Enter again to use it anyway.", describeStrength(res, self.minBits))
+		}
+	}
+
+	return nil
+}
+
+/*
+Roughly how long CalcSiteHash takes on this machine: time the same mbcrypt
+with 1/16 of the cost.
+*/
+func estimateHashTime() time.Duration {
+	start := time.Now()
+	mbcrypt.Hash(type1.KDFThreads, []byte("calibrate"), make([]byte, 16), type1.KDFCost - 4)
+	return time.Since(start) * 16
+}
+
+/*
+Calculate the site hash showing an estimated progress bar.  Returns false
+if the user quit.
+*/
+func (self *tuiApp) runHash(keys <-chan []tui.Key) (type1.SiteResult, bool) {
+	site := self.fields[fieldSite].Text()
+	sites := []type1.SiteInput{{Sitename: site, Personalization: self.fields[fieldPers].Text()}}
+
+	//the only conversion of the password
+	rawPass := self.fields[fieldPass].Bytes()
+	self.fields[fieldPass].Erase()
+	passBytes := bytes.TrimSpace(rawPass)
+
+	//before starting so the two don't compete for the CPU
+	expected := estimateHashTime()
+
+	//stderr would garble the screen in raw mode
+	var warnings []string
+	warn := func(msg string) {
+		warnings = append(warnings, "Warning: " + msg)
+	}
+
+	done := make(chan type1.SiteResult, 1)
+	go func() {
+		defer util.Erase(rawPass)
+
+		var cache *sitecache.Cache
+		if self.cacheFile != "" {
+			cache = openCache(self.cacheFile, passBytes, self.opt, warn)
+			if cache != nil {
+				defer cache.Erase()
+			}
+		}
+
+		done <- calcSiteHashesCached(cache, self.cacheTTL, passBytes, sites, self.opt, warn)[0]
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	barWidth := 50
+	if self.width > 0 && self.width - 4 < barWidth {
+		barWidth = self.width - 4
+	}
+
+	for {
+		drawScreen([]string{
+			"",
+			"  Calculating the coordinates for " + type1.NormalizeField(site) + "...",
+			"",
+			"  " + tui.ProgressBar(tui.EstimatedFraction(time.Since(start), expected), barWidth),
+		})
+
+		select {
+		case res := <-done:
+			//warnings is complete once the result arrives
+			self.message = strings.Join(warnings, "\n")
+			return res, true
+		case batch, ok := <-keys:
+			if !ok {
+				return type1.SiteResult{}, false
+			}
+			for _, key := range batch {
+				if key.Code == tui.KeyEsc || key.Code == tui.KeyCtrlC {
+					return type1.SiteResult{}, false
+				}
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+Show the coordinates until a key is pressed or clearAfter elapses.
+*/
+func (self *tuiApp) showCoords(coords []string, keys <-chan []tui.Key, clearAfter time.Duration) {
+	title := "Word coordinates:"
+	if self.opt.CardId != "" {
+		title = "Word coordinates for card " + self.opt.CardId + ":"
+	}
+
+	deadline := time.Now().Add(clearAfter)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		left := time.Until(deadline)
+		if left <= 0 {
+			return
+		}
+
+		lines := []string{
+			"",
+			"  " + title,
+			"",
+			"    " + ansiBold + strings.Join(coords, "   ") + ansiReset,
+			"",
+			"  Remember:",
+			"    1. Beware of Phishing!  Don't log in via email links.",
+			"    2. Capitalize the first word.",
+			"    3. End with one digit.",
+			"    4. No spaces.",
+			"",
+			fmt.Sprintf("  Clearing in %ds.  Press any key to clear now.", int(left.Seconds() + 0.5)),
+		}
+		if self.message != "" {
+			lines = append(lines, "")
+			for _, msg := range strings.Split(self.message, "\n") {
+				lines = append(lines, "  " + ansiYellow + msg + ansiReset)
+			}
+		}
+		drawScreen(lines)
+
+		select {
+		case <-keys:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+Full-screen terminal UI: like `passn coords` but with live checkword feedback,
+sitename suggestions and coordinates which clear themselves.
+*/
+func doTUI(args []string) {
+	fs := newFlagSet("tui")
+	gHashFlags.register(fs)
+	clearAfter := fs.Duration("clear", 60 * time.Second, "Clear the coordinates from the screen after this long")
+	history := fs.Bool("history", false, "Suggest and remember sitenames.  They are saved in plaintext, revealing which sites you use")
+	historyFile := fs.String("historyfile", "", "History file for -history (default $XDG_DATA_HOME/passillion/history)")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		commandUsage("tui", args)
+	}
+
+	hf := gHashFlags
+	layout, opt := hf.settings()
+
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		log.Fatal("passn tui needs a terminal")
+	}
+
+	ensureSelfTest()
+
+	app := &tuiApp{
+		fields: []*tui.Field{
+			{Label: "Sitename"},
+			{Label: "Personalization"},
+			{Label: "Password", Masked: true},
+		},
+		nWords: hf.nWords,
+		typoHints: hf.typoHints,
+		keyboard: hf.keyboard,
+		minBits: hf.minBits,
+		refuseWeak: hf.refuseWeak,
+		layout: layout,
+		opt: opt,
+		cacheFile: hf.cacheSetting(),
+		cacheTTL: hf.cacheTTL,
+	}
+	defer app.fields[fieldPass].Erase()
+
+	historyPath := ""
+	if *history || *historyFile != "" {
+		var err error
+		historyPath = *historyFile
+		if historyPath == "" {
+			if historyPath, err = tui.DefaultHistoryPath(); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if app.history, err = tui.LoadHistory(historyPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if width, _, err := terminal.GetSize(fd); err == nil {
+		app.width = width
+	}
+
+	oldState, err := terminal.MakeRaw(fd)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(ansiAltScreen)
+	restore := func() {
+		fmt.Print(ansiClear + ansiMainScreen)
+		terminal.Restore(fd, oldState)
+	}
+
+	keys := make(chan []tui.Key)
+	go readKeys(keys)
+
+	if !app.runForm(keys) {
+		restore()
+		return
+	}
+
+	res, ok := app.runHash(keys)
+	if !ok {
+		restore()
+		return
+	}
+	if res.Err != nil {
+		restore()
+		if app.message != "" {
+			fmt.Fprintln(os.Stderr, app.message)
+		}
+		log.Fatal(res.Err)
+	}
+
+	coords, err := type1.GetWordCoordinatesWithOptions(res.Hash, app.nWords, layout, opt)
+	util.Erase(res.Hash)
+	if err != nil {
+		restore()
+		log.Fatal(err)
+	}
+
+	if app.history != nil {
+		app.history.Add(type1.NormalizeField(app.fields[fieldSite].Text()))
+		if err = app.history.Save(historyPath); err != nil {
+			app.addMessage("Warning: could not save the history: " + err.Error())
+		}
+	}
+
+	app.showCoords(coords, keys, *clearAfter)
+	restore()
+
+	//again now that the screen is cleared
+	if app.message != "" {
+		fmt.Fprintln(os.Stderr, app.message)
+	}
+	fmt.Println("Coordinates cleared.")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"github.com/cruxic/passillion/go/webui"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+/*
+Serve the embedded web calculator.
+*/
+func doWeb(args []string) {
+	fs := newFlagSet("web")
+	addr := fs.String("addr", "127.0.0.1:7777", "Listen address.  Other machines can only connect if it is not a loopback address.")
+	fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		commandUsage("web", args)
+	}
+
+	handler, err := webui.Handler()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	host, _, err := net.SplitHostPort(*addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		fmt.Fprintf(os.Stderr, "Warning: %s is reachable from other machines and the connection is not encrypted.\n", *addr)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Serving the calculator at http://%s%s (Ctrl+C to stop)\n", listener.Addr().String(), webui.IndexPage)
+
+	server := &http.Server{
+		Handler: handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Fatal(server.Serve(listener))
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/cruxic/passillion/go/wordlist"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+func doWordlist(args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "build":
+			doWordlistBuild(args[1:])
+			return
+		case "check":
+			doWordlistCheck(args[1:])
+			return
+		}
+	}
+
+	commandUsage("wordlist", args)
+}
+
+/*
+Read every word of a file ("-" means stdin).  Words are separated by
+whitespace because "aspell expand" puts all forms of a word on one line.
+*/
+func readWords(path string) ([]string, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		words = append(words, strings.Fields(scanner.Text())...)
+	}
+
+	return words, scanner.Err()
+}
+
+/*
+Turn a raw dictionary (see word-lists/dump-aspell-dictionary.sh) into a
+canonical word list.
+*/
+func doWordlistBuild(args []string) {
+	def := wordlist.DefaultBuildOptions()
+
+	fs := newFlagSet("wordlist build")
+	in := fs.String("in", "-", "Raw dictionary, whitespace separated words (- for stdin)")
+	out := fs.String("out", "-", "Output file (- for stdout)")
+	minLen := fs.Int("min", def.MinLen, "Minimum word length")
+	maxLen := fs.Int("max", def.MaxLen, "Maximum word length")
+	charset := fs.String("charset", def.Charset, "Allowed characters")
+	blocklist := fs.String("blocklist", "", "File of additional words to reject")
+	keepProper := fs.Bool("keepproper", false, "Keep capitalized entries (proper nouns)")
+	keepHomophones := fs.Bool("keephomophones", false, "Keep words which sound like another (sea and see)")
+	keepPlurals := fs.Bool("keepplurals", false, "Keep plurals of other words")
+	minDist := fs.Int("mindist", def.MinEditDistance, "Reject words closer than this edit distance to another word (0 disables)")
+	count := fs.Int("count", 0, "Choose this many words (requires -seed)")
+	seedHex := fs.String("seed", "", "Hex seed which determines the words chosen by -count")
+	fs.Parse(args)
+
+	raw, err := readWords(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opt := wordlist.BuildOptions{
+		MinLen: *minLen,
+		MaxLen: *maxLen,
+		Charset: *charset,
+		DropProperNouns: !*keepProper,
+		DedupeHomophones: !*keepHomophones,
+		DedupePlurals: !*keepPlurals,
+		MinEditDistance: *minDist,
+		Count: *count,
+	}
+
+	if len(*blocklist) > 0 {
+		opt.Blocklist, err = readWords(*blocklist)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(*seedHex) > 0 {
+		opt.Seed, err = hex.DecodeString(strings.TrimSpace(*seedHex))
+		if err != nil {
+			log.Fatal("invalid -seed: ", err)
+		}
+	}
+
+	words, err := wordlist.Build(raw, opt)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w := os.Stdout
+	if *out != "-" {
+		w, err = os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer w.Close()
+	}
+
+	if err = wordlist.Write(w, words); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d words, sha256 %s\n", len(words), wordlist.Hash(words))
+}
+
+//Print at most max items.
+func printLimited(items []string, max int) {
+	for i, item := range items {
+		if i == max {
+			fmt.Printf("    ... and %d more\n", len(items) - max)
+			break
+		}
+		fmt.Printf("    %s\n", item)
+	}
+}
+
+func joinGroups(groups [][]string) []string {
+	res := make([]string, len(groups))
+	for i, g := range groups {
+		res[i] = strings.Join(g, " ")
+	}
+	return res
+}
+
+/*
+Report objective quality measures of a card word list and exit with
+status 1 if any threshold is exceeded.
+*/
+func doWordlistCheck(args []string) {
+	def := wordlist.DefaultThresholds()
+
+	fs := newFlagSet("wordlist check")
+	listName := fs.String("list", wordlist.Standard, "Word list: \"standard\", \"aspell4\", a file with one word per line or a .ts file")
+	minDist := fs.Int("mindist", def.MinEditDistance, "Minimum edit distance between any two words")
+	prefixLen := fs.Int("prefixlen", def.MaxUniquePrefixLen, "Words must be unique in their first N letters")
+	maxSoundex := fs.Int("maxsoundex", def.MaxSoundexCollisions, "Maximum words sharing a Soundex code")
+	maxMetaphone := fs.Int("maxmetaphone", def.MaxMetaphoneCollisions, "Maximum words sharing a Metaphone code")
+	maxOverlaps := fs.Int("maxoverlaps", def.MaxOverlaps, "Maximum words found inside other words or word pairs")
+	maxNonASCII := fs.Int("maxnonascii", def.MaxNonASCII, "Maximum words with non-ASCII characters")
+	//(a negative threshold disables the check)
+	show := fs.Int("show", 10, "Number of examples to show for each measure")
+	fs.Parse(args)
+
+	words, err := wordlist.Get(*listName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := wordlist.Analyze(words)
+
+	fmt.Printf("Words: %d\n", r.Count)
+
+	fmt.Printf("Minimum edit distance: %d\n", r.MinEditDistance)
+	pairs := make([]string, len(r.ClosestPairs))
+	for i, p := range r.ClosestPairs {
+		pairs[i] = p[0] + " " + p[1]
+	}
+	printLimited(pairs, *show)
+
+	fmt.Printf("Unique prefix length: %d\n", r.UniquePrefixLen)
+
+	fmt.Printf("Soundex collisions: %d groups\n", len(r.SoundexCollisions))
+	printLimited(joinGroups(r.SoundexCollisions), *show)
+
+	fmt.Printf("Metaphone collisions: %d groups\n", len(r.MetaphoneCollisions))
+	printLimited(joinGroups(r.MetaphoneCollisions), *show)
+
+	fmt.Printf("Words inside other words: %d\n", len(r.Overlaps))
+	overlaps := make([]string, len(r.Overlaps))
+	for i, o := range r.Overlaps {
+		overlaps[i] = o.Word + " in " + strings.Join(o.Within, ", ")
+	}
+	printLimited(overlaps, *show)
+
+	fmt.Printf("Non-ASCII words: %d\n", len(r.NonASCII))
+	printLimited(r.NonASCII, *show)
+
+	failures := r.Failures(wordlist.Thresholds{
+		MinEditDistance: *minDist,
+		MaxUniquePrefixLen: *prefixLen,
+		MaxSoundexCollisions: *maxSoundex,
+		MaxMetaphoneCollisions: *maxMetaphone,
+		MaxOverlaps: *maxOverlaps,
+		MaxNonASCII: *maxNonASCII,
+	})
+
+	if len(failures) > 0 {
+		fmt.Println()
+		for _, f := range failures {
+			fmt.Printf("FAIL: %s\n", f)
+		}
+		os.Exit(exitFailure)
+	}
+
+	fmt.Println("\nOK")
+}
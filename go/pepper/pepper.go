@@ -0,0 +1,168 @@
+/*
+A machine-local secret ("pepper") mixed into the salt of every site hash
+(see type1.Options.Pepper).  It is stored in a file readable only by its
+owner and can be copied between trusted machines with Export and Import.
+*/
+package pepper
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/mnemonic"
+	"github.com/cruxic/passillion/go/util"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const PepperLen = 32
+
+/*
+$XDG_DATA_HOME/passillion/pepper, or ~/.local/share/passillion/pepper when
+XDG_DATA_HOME is not set.
+*/
+func DefaultPath() (string, error) {
+	dir, err := util.UserDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "passillion", "pepper"), nil
+}
+
+/*
+Write the pepper to a new file (mode 0600) creating its directory (0700).
+An existing file is only replaced if overwrite is true.
+*/
+func Save(path string, pepper []byte, overwrite bool) error {
+	if len(pepper) != PepperLen {
+		return fmt.Errorf("pepper must be %d bytes", PepperLen)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	if !overwrite {
+		return writeFile(path, os.O_EXCL, pepper)
+	}
+
+	//write then rename so a crash never leaves the old pepper half replaced
+	tmp := path + ".tmp"
+	if err := writeFile(tmp, os.O_TRUNC, pepper); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+//Write the pepper as hex.  flag is O_EXCL or O_TRUNC.
+func writeFile(path string, flag int, pepper []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY | os.O_CREATE | flag, 0600)
+	if err != nil {
+		return err
+	}
+
+	//O_CREATE does not change the mode of an existing file
+	err = f.Chmod(0600)
+	if err == nil {
+		_, err = fmt.Fprintf(f, "%s\n", hex.EncodeToString(pepper))
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+
+	return err
+}
+
+/*
+Generate a new random pepper and Save() it.  Never overwrites.
+*/
+func Init(path string, rng util.ByteSource) ([]byte, error) {
+	pepper := make([]byte, PepperLen)
+	for i := range pepper {
+		b, err := rng.NextByte()
+		if err != nil {
+			return nil, err
+		}
+		pepper[i] = b
+	}
+
+	if err := Save(path, pepper, false); err != nil {
+		util.Erase(pepper)
+		return nil, err
+	}
+
+	return pepper, nil
+}
+
+/*
+Read the pepper.  Like ssh private keys, the file is refused if other
+users can read or write it.
+*/
+func Load(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Mode().Perm() & 0077 != 0 {
+		return nil, fmt.Errorf("%s is accessible by other users (chmod 600 it)", path)
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer util.Erase(raw)
+
+	pepper, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil || len(pepper) != PepperLen {
+		return nil, fmt.Errorf("%s is not a pepper file", path)
+	}
+
+	return pepper, nil
+}
+
+/*
+Mnemonic words for writing the pepper down or typing it on another machine.
+*/
+func Export(pepper []byte) (string, error) {
+	words, err := mnemonic.Encode(pepper)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(words, " "), nil
+}
+
+/*
+Parse the output of Export() (or 64 hex digits).
+*/
+func Parse(s string) ([]byte, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 1 {
+		if pepper, err := hex.DecodeString(fields[0]); err == nil {
+			if len(pepper) != PepperLen {
+				return nil, fmt.Errorf("pepper must be %d bytes", PepperLen)
+			}
+			return pepper, nil
+		}
+	}
+
+	pepper, err := mnemonic.Decode(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pepper) != PepperLen {
+		return nil, errors.New("those words are not a pepper")
+	}
+
+	return pepper, nil
+}
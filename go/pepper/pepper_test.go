@@ -0,0 +1,110 @@
+package pepper
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/cruxic/passillion/go/util"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func Test_DefaultPath(t *testing.T) {
+	assert := assert.New(t)
+
+	defer os.Setenv("XDG_DATA_HOME", os.Getenv("XDG_DATA_HOME"))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+
+	os.Setenv("XDG_DATA_HOME", "/x/data")
+	path, err := DefaultPath()
+	assert.NoError(err)
+	assert.Equal("/x/data/passillion/pepper", path)
+
+	os.Setenv("XDG_DATA_HOME", "")
+	os.Setenv("HOME", "/home/me")
+	path, err = DefaultPath()
+	assert.NoError(err)
+	assert.Equal("/home/me/.local/share/passillion/pepper", path)
+}
+
+func Test_InitLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "pepper")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sub", "pepper")
+	rng := &util.FixedByteSource{Bytes: util.ByteSequence(0, 64)}
+	pepper, err := Init(path, rng)
+	assert.NoError(err)
+	assert.Equal(util.ByteSequence(0, 32), pepper)
+
+	info, err := os.Stat(path)
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+	info, err = os.Stat(filepath.Join(dir, "sub"))
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0700), info.Mode().Perm())
+
+	loaded, err := Load(path)
+	assert.NoError(err)
+	assert.Equal(pepper, loaded)
+
+	//never overwritten by Init
+	_, err = Init(path, rng)
+	assert.Error(err)
+
+	//Save can replace it
+	assert.NoError(Save(path, util.ByteSequence(1, 32), true))
+	loaded, _ = Load(path)
+	assert.Equal(util.ByteSequence(1, 32), loaded)
+
+	//via a temp file, even a stale one
+	assert.NoError(ioutil.WriteFile(path + ".tmp", []byte("stale"), 0644))
+	assert.NoError(Save(path, util.ByteSequence(2, 32), true))
+	loaded, _ = Load(path)
+	assert.Equal(util.ByteSequence(2, 32), loaded)
+	_, err = os.Stat(path + ".tmp")
+	assert.True(os.IsNotExist(err))
+
+	//refuse a file others can read
+	assert.NoError(os.Chmod(path, 0644))
+	_, err = Load(path)
+	assert.Error(err)
+
+	//Save fixes the mode
+	assert.NoError(Save(path, pepper, true))
+	_, err = Load(path)
+	assert.NoError(err)
+
+	//garbage
+	assert.NoError(ioutil.WriteFile(path, []byte("hello\n"), 0600))
+	_, err = Load(path)
+	assert.Error(err)
+
+	assert.Error(Save(path, []byte("short"), true))
+}
+
+func Test_ExportParse(t *testing.T) {
+	assert := assert.New(t)
+
+	pepper := util.ByteSequence(100, 32)
+	words, err := Export(pepper)
+	assert.NoError(err)
+
+	parsed, err := Parse(words)
+	assert.NoError(err)
+	assert.Equal(pepper, parsed)
+
+	parsed, err = Parse(" 6465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f80818283 \n")
+	assert.NoError(err)
+	assert.Equal(pepper, parsed)
+
+	_, err = Parse("0001")
+	assert.Error(err)
+
+	//mnemonic of the wrong length
+	_, err = Parse("able anti clap scat awry bunt join akin beak dark scar fess bled safe")
+	assert.Error(err)
+}
@@ -0,0 +1,254 @@
+/*
+A minimal client for pinentry programs (pinentry-gnome3, pinentry-qt,
+pinentry-curses, pinentry-mac...) which ask for a password in a dialog.
+They speak the Assuan protocol on stdin/stdout: one command per line,
+answered by "OK", "ERR <code> <description>" or "D <data>" lines followed
+by "OK".  Only the commands needed to ask for a password are implemented.
+*/
+package pinentry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/util"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+//Used when no program is configured.
+const DefaultProgram = "pinentry"
+
+//Assuan lines (including the newline) may not be longer than this.
+const maxLineLen = 1000
+
+//The user closed the dialog or pressed Cancel.
+var ErrCancelled = errors.New("pinentry: cancelled")
+
+//libgpg-error codes (the low 16 bits of an ERR code)
+const (
+	gpgErrTimeout = 62
+	gpgErrCanceled = 99
+)
+
+type Client struct {
+	cmd *exec.Cmd
+	in io.WriteCloser
+	out io.Reader
+
+	//current response line (may contain the password)
+	line []byte
+}
+
+/*
+Start a pinentry program and wait for its greeting.
+*/
+func Open(program string, args ...string) (*Client, error) {
+	if program == "" {
+		program = DefaultProgram
+	}
+
+	cmd := exec.Command(program, args...)
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	self := &Client{cmd: cmd, in: in, out: out, line: make([]byte, 0, maxLineLen)}
+	if _, err = self.readResponse(); err != nil {
+		self.Close()
+		return nil, fmt.Errorf("%s: %s", program, err.Error())
+	}
+
+	return self, nil
+}
+
+//Percent-escape the characters Assuan does not allow in a line.
+func escape(s string) string {
+	s = strings.Replace(s, "%", "%25", -1)
+	s = strings.Replace(s, "\r", "%0D", -1)
+	return strings.Replace(s, "\n", "%0A", -1)
+}
+
+//-1 if not a hex digit
+func hexValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'f':
+		return int(c - 'a' + 10)
+	case c >= 'A' && c <= 'F':
+		return int(c - 'A' + 10)
+	}
+	return -1
+}
+
+/*
+Decode %XX escapes, appending to dest.  Invalid escapes are copied as is.
+*/
+func unescape(dest, src []byte) []byte {
+	for i := 0; i < len(src); i++ {
+		if src[i] == '%' && i + 2 < len(src) {
+			hi, lo := hexValue(src[i+1]), hexValue(src[i+2])
+			if hi >= 0 && lo >= 0 {
+				dest = append(dest, byte(hi << 4 | lo))
+				i += 2
+				continue
+			}
+		}
+		dest = append(dest, src[i])
+	}
+	return dest
+}
+
+/*
+Read one line into self.line without the newline.  Bytes are read one at a
+time so that no copy of the password is left in a read buffer.
+*/
+func (self *Client) readLine() error {
+	util.Erase(self.line[:cap(self.line)])
+	self.line = self.line[:0]
+
+	b := make([]byte, 1)
+	for {
+		if _, err := io.ReadFull(self.out, b); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return errors.New("pinentry: unexpected end of output")
+			}
+			return err
+		}
+		if b[0] == '\n' {
+			return nil
+		}
+		if len(self.line) >= maxLineLen {
+			return errors.New("pinentry: line too long")
+		}
+		self.line = append(self.line, b[0])
+	}
+}
+
+/*
+Read lines until OK or ERR, returning the decoded data of any D lines.
+*/
+func (self *Client) readResponse() ([]byte, error) {
+	//big enough that a one line password is never reallocated (leaving a copy)
+	data := make([]byte, 0, maxLineLen)
+	for {
+		if err := self.readLine(); err != nil {
+			util.Erase(data)
+			return nil, err
+		}
+		line := self.line
+
+		//careful not to copy D lines into strings
+		switch {
+		case bytes.Equal(line, []byte("OK")) || bytes.HasPrefix(line, []byte("OK ")):
+			return data, nil
+		case bytes.HasPrefix(line, []byte("D ")):
+			data = unescape(data, line[2:])
+		case bytes.Equal(line, []byte("ERR")) || bytes.HasPrefix(line, []byte("ERR ")):
+			util.Erase(data)
+			return nil, parseErr(string(line))
+		case len(line) == 0 || line[0] == '#' || line[0] == 'S':
+			//comments and status lines
+		default:
+			util.Erase(data)
+			return nil, fmt.Errorf("pinentry: unexpected response \"%s\"", string(line))
+		}
+	}
+}
+
+//"ERR 83886179 Operation cancelled <Pinentry>"
+func parseErr(line string) error {
+	fields := strings.SplitN(line, " ", 3)
+	code := 0
+	if len(fields) >= 2 {
+		code, _ = strconv.Atoi(fields[1])
+	}
+
+	switch code & 0xFFFF {
+	case gpgErrCanceled, gpgErrTimeout:
+		return ErrCancelled
+	}
+
+	if len(fields) == 3 {
+		return fmt.Errorf("pinentry: %s", fields[2])
+	}
+	return fmt.Errorf("pinentry: %s", line)
+}
+
+//Send a command and wait for OK.
+func (self *Client) command(name, arg string) error {
+	line := name
+	if arg != "" {
+		line += " " + escape(arg)
+	}
+	if len(line) + 1 > maxLineLen {
+		return fmt.Errorf("pinentry: %s argument too long", name)
+	}
+
+	if _, err := io.WriteString(self.in, line + "\n"); err != nil {
+		return err
+	}
+
+	data, err := self.readResponse()
+	util.Erase(data)
+	return err
+}
+
+//Window title
+func (self *Client) SetTitle(title string) error {
+	return self.command("SETTITLE", title)
+}
+
+//Text shown above the input, eg what the password is for
+func (self *Client) SetDesc(desc string) error {
+	return self.command("SETDESC", desc)
+}
+
+//Label of the input
+func (self *Client) SetPrompt(prompt string) error {
+	return self.command("SETPROMPT", prompt)
+}
+
+/*
+Show an error (eg a wrong checkword) with the next GetPin.  Newlines are
+allowed.
+*/
+func (self *Client) SetError(msg string) error {
+	return self.command("SETERROR", msg)
+}
+
+/*
+Show the dialog and return what was entered.  The caller must erase the
+result.  Returns ErrCancelled if the user cancelled.
+*/
+func (self *Client) GetPin() ([]byte, error) {
+	if _, err := io.WriteString(self.in, "GETPIN\n"); err != nil {
+		return nil, err
+	}
+
+	pin, err := self.readResponse()
+	util.Erase(self.line[:cap(self.line)])
+	return pin, err
+}
+
+/*
+Say BYE and wait for the program to exit.
+*/
+func (self *Client) Close() error {
+	util.Erase(self.line[:cap(self.line)])
+
+	io.WriteString(self.in, "BYE\n")  //it may already be gone
+	self.in.Close()
+	return self.cmd.Wait()
+}
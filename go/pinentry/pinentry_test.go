@@ -0,0 +1,169 @@
+package pinentry
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+/*
+Write a stand-in for a pinentry program.  It logs every command to
+dir/log and answers each GETPIN with the next line of dir/pins (already
+escaped) or cancels when there are no more.  "SETPROMPT fail" gets an ERR.
+*/
+func fakePinentry(t *testing.T, dir string, pins ...string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs /bin/sh")
+	}
+
+	logPath := filepath.Join(dir, "log")
+	pinsPath := filepath.Join(dir, "pins")
+	if err := ioutil.WriteFile(pinsPath, []byte(strings.Join(pins, "\n") + "\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	script := fmt.Sprintf(`#!/bin/sh
+n=0
+echo "OK Pleased to meet you"
+while read -r line; do
+	echo "$line" >> '%s'
+	cmd=${line%%%% *}
+	arg=${line#* }
+	case "$cmd" in
+	GETPIN)
+		n=$((n + 1))
+		pin=$(sed -n "${n}p" '%s')
+		if [ -z "$pin" ]; then
+			echo "ERR 83886179 Operation cancelled <Pinentry>"
+		else
+			echo "S SOME-STATUS"
+			echo "D $pin"
+			echo "OK"
+		fi
+		;;
+	SETPROMPT)
+		if [ "$arg" = "fail" ]; then
+			echo "ERR 536870912 General error <Pinentry>"
+		else
+			echo "OK"
+		fi
+		;;
+	BYE)
+		echo "OK closing connection"
+		exit 0
+		;;
+	*)
+		echo "# comment"
+		echo "OK"
+		;;
+	esac
+done
+`, logPath, pinsPath)
+
+	path := filepath.Join(dir, "fake-pinentry")
+	if err := ioutil.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readLog(dir string) string {
+	data, _ := ioutil.ReadFile(filepath.Join(dir, "log"))
+	return string(data)
+}
+
+func Test_Escape(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("100%25%0Asure%0D", escape("100%\nsure\r"))
+	assert.Equal("plain text", escape("plain text"))
+
+	assert.Equal([]byte("100%\nsure"), unescape(nil, []byte("100%25%0asure")))
+	assert.Equal([]byte("ab%zz%4"), unescape([]byte("a"), []byte("b%zz%4")))
+	assert.Equal(0, len(unescape(nil, nil)))
+}
+
+func Test_GetPin(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "pinentry")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	prog := fakePinentry(t, dir, "Super Secret%25dog", "second")
+
+	c, err := Open(prog)
+	assert.NoError(err)
+
+	assert.NoError(c.SetTitle("passn"))
+	assert.NoError(c.SetDesc("Coordinates for\nexample.com (100%)"))
+	assert.NoError(c.SetPrompt("Coordinate Password:"))
+
+	pin, err := c.GetPin()
+	assert.NoError(err)
+	assert.Equal("Super Secret%dog", string(pin))
+
+	//error shown with the retry
+	assert.NoError(c.SetError("Wrong checkword.\nHint: swapped letters."))
+	pin, err = c.GetPin()
+	assert.NoError(err)
+	assert.Equal("second", string(pin))
+
+	//line buffer erased
+	for _, b := range c.line[:cap(c.line)] {
+		assert.Equal(byte(0), b)
+	}
+
+	//no more pins: the user cancelled
+	pin, err = c.GetPin()
+	assert.Equal(ErrCancelled, err)
+	assert.Nil(pin)
+
+	err = c.SetPrompt("fail")
+	assert.EqualError(err, "pinentry: General error <Pinentry>")
+
+	assert.NoError(c.Close())
+
+	assert.Equal(`SETTITLE passn
+SETDESC Coordinates for%0Aexample.com (100%25)
+SETPROMPT Coordinate Password:
+GETPIN
+SETERROR Wrong checkword.%0AHint: swapped letters.
+GETPIN
+GETPIN
+SETPROMPT fail
+BYE
+`, readLog(dir))
+}
+
+func Test_OpenErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "pinentry")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	_, err = Open(filepath.Join(dir, "does-not-exist"))
+	assert.Error(err)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("needs /bin/sh")
+	}
+
+	//exits without a greeting
+	prog := filepath.Join(dir, "silent")
+	assert.NoError(ioutil.WriteFile(prog, []byte("#!/bin/sh\nexit 0\n"), 0700))
+	_, err = Open(prog)
+	assert.EqualError(err, prog + ": pinentry: unexpected end of output")
+
+	//not a pinentry
+	prog = filepath.Join(dir, "chatty")
+	assert.NoError(ioutil.WriteFile(prog, []byte("#!/bin/sh\necho hello\n"), 0700))
+	_, err = Open(prog)
+	assert.EqualError(err, prog + ": pinentry: unexpected response \"hello\"")
+}
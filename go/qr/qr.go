@@ -0,0 +1,500 @@
+/*
+A small QR code encoder (ISO/IEC 18004) for showing short text such as
+word coordinates in a terminal.
+
+Only versions 1 to 10 (up to 21x21 to 57x57 modules) and the alphanumeric
+and byte modes are supported, which is plenty for a few hundred characters.
+*/
+package qr
+
+import (
+	"errors"
+	"strings"
+)
+
+//Error correction level
+type Level int
+
+const (
+	L Level = iota //recovers 7% of codewords
+	M              //15%
+	Q              //25%
+	H              //30%
+)
+
+//The 2 bit level indicator of the format information
+var gLevelBits = [4]int{1, 0, 3, 2}
+
+const maxVersion = 10
+
+/*
+Error correction block structure of one version and level.  The data is
+split into blocks1 blocks of data1 codewords followed by blocks2 blocks of
+data1+1 codewords.  Each block gets ec error correction codewords.
+*/
+type blockInfo struct {
+	ec      int
+	blocks1 int
+	data1   int
+	blocks2 int
+}
+
+//Indexed by version-1 then Level
+var gBlocks = [maxVersion][4]blockInfo{
+	{{7, 1, 19, 0}, {10, 1, 16, 0}, {13, 1, 13, 0}, {17, 1, 9, 0}},
+	{{10, 1, 34, 0}, {16, 1, 28, 0}, {22, 1, 22, 0}, {28, 1, 16, 0}},
+	{{15, 1, 55, 0}, {26, 1, 44, 0}, {18, 2, 17, 0}, {22, 2, 13, 0}},
+	{{20, 1, 80, 0}, {18, 2, 32, 0}, {26, 2, 24, 0}, {16, 4, 9, 0}},
+	{{26, 1, 108, 0}, {24, 2, 43, 0}, {18, 2, 15, 2}, {22, 2, 11, 2}},
+	{{18, 2, 68, 0}, {16, 4, 27, 0}, {24, 4, 19, 0}, {28, 4, 15, 0}},
+	{{20, 2, 78, 0}, {18, 4, 31, 0}, {18, 2, 14, 4}, {26, 4, 13, 1}},
+	{{24, 2, 97, 0}, {22, 2, 38, 2}, {22, 4, 18, 2}, {26, 4, 14, 2}},
+	{{30, 2, 116, 0}, {22, 3, 36, 2}, {20, 4, 16, 4}, {24, 4, 12, 4}},
+	{{18, 2, 68, 2}, {26, 4, 43, 1}, {24, 6, 19, 2}, {28, 6, 15, 2}},
+}
+
+//Alignment pattern center coordinates, indexed by version-1
+var gAlignment = [maxVersion][]int{
+	{},
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+}
+
+func (self blockInfo) dataCodewords() int {
+	return self.blocks1 * self.data1 + self.blocks2 * (self.data1 + 1)
+}
+
+const alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+func isAlphanumeric(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte(alphanumericChars, text[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type bitBuffer struct {
+	bits []bool
+}
+
+func (self *bitBuffer) append(value, nBits int) {
+	for i := nBits - 1; i >= 0; i-- {
+		self.bits = append(self.bits, (value >> uint(i)) & 1 == 1)
+	}
+}
+
+func (self *bitBuffer) bytes() []byte {
+	res := make([]byte, (len(self.bits) + 7) / 8)
+	for i, bit := range self.bits {
+		if bit {
+			res[i / 8] |= 0x80 >> uint(i % 8)
+		}
+	}
+	return res
+}
+
+/*
+Encode the text into data segments (mode indicator, character count and
+data) for the given version.
+*/
+func encodeSegment(text string, version int) *bitBuffer {
+	buf := &bitBuffer{}
+
+	if isAlphanumeric(text) {
+		countBits := 9
+		if version >= 10 {
+			countBits = 11
+		}
+
+		buf.append(2, 4)  //0010
+		buf.append(len(text), countBits)
+
+		for i := 0; i + 1 < len(text); i += 2 {
+			a := strings.IndexByte(alphanumericChars, text[i])
+			b := strings.IndexByte(alphanumericChars, text[i+1])
+			buf.append(a * 45 + b, 11)
+		}
+
+		if len(text) % 2 == 1 {
+			buf.append(strings.IndexByte(alphanumericChars, text[len(text)-1]), 6)
+		}
+	} else {
+		countBits := 8
+		if version >= 10 {
+			countBits = 16
+		}
+
+		buf.append(4, 4)  //0100
+		buf.append(len(text), countBits)
+		for i := 0; i < len(text); i++ {
+			buf.append(int(text[i]), 8)
+		}
+	}
+
+	return buf
+}
+
+/*
+Choose the smallest version which fits and return the padded data codewords.
+*/
+func encodeData(text string, level Level) (version int, data []byte, err error) {
+	for version = 1; version <= maxVersion; version++ {
+		capacity := gBlocks[version-1][level].dataCodewords() * 8
+
+		buf := encodeSegment(text, version)
+		if len(buf.bits) > capacity {
+			continue
+		}
+
+		//terminator of up to 4 zeros, then pad to a whole byte
+		for i := 0; i < 4 && len(buf.bits) < capacity; i++ {
+			buf.bits = append(buf.bits, false)
+		}
+		for len(buf.bits) % 8 != 0 {
+			buf.bits = append(buf.bits, false)
+		}
+
+		//alternating pad bytes
+		data = buf.bytes()
+		for pad := byte(0xEC); len(data) < capacity / 8; pad ^= 0xEC ^ 0x11 {
+			data = append(data, pad)
+		}
+
+		return version, data, nil
+	}
+
+	return 0, nil, errors.New("text is too long for a QR code")
+}
+
+/*
+Split the data into blocks, append error correction to each and interleave.
+*/
+func addErrorCorrection(data []byte, version int, level Level) []byte {
+	info := gBlocks[version-1][level]
+	nBlocks := info.blocks1 + info.blocks2
+	gen := rsGenerator(info.ec)
+
+	var blocks [][]byte
+	var ecBlocks [][]byte
+	k := 0
+	for b := 0; b < nBlocks; b++ {
+		n := info.data1
+		if b >= info.blocks1 {
+			n++
+		}
+		block := data[k:k+n]
+		k += n
+
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, gen))
+	}
+
+	var res []byte
+	for i := 0; i <= info.data1; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				res = append(res, block[i])
+			}
+		}
+	}
+	for i := 0; i < info.ec; i++ {
+		for _, ec := range ecBlocks {
+			res = append(res, ec[i])
+		}
+	}
+
+	return res
+}
+
+/*
+A QR code symbol.
+*/
+type Code struct {
+	Version int
+	Level Level
+	Mask int
+
+	//Width and height in modules
+	Size int
+
+	//[row][column], true is dark
+	modules [][]bool
+
+	//true for finder, timing, alignment, format and version modules
+	function [][]bool
+}
+
+//Report whether the module at column x, row y is dark.
+func (self *Code) Black(x, y int) bool {
+	return self.modules[y][x]
+}
+
+func newCode(version int, level Level) *Code {
+	size := version * 4 + 17
+	c := &Code{
+		Version: version,
+		Level: level,
+		Size: size,
+		modules: make([][]bool, size),
+		function: make([][]bool, size),
+	}
+	for y := 0; y < size; y++ {
+		c.modules[y] = make([]bool, size)
+		c.function[y] = make([]bool, size)
+	}
+	return c
+}
+
+func (self *Code) setFunction(x, y int, dark bool) {
+	self.modules[y][x] = dark
+	self.function[y][x] = true
+}
+
+//Finder pattern (with its light separator) centered at x, y
+func (self *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x + dx, y + dy
+			if xx < 0 || yy < 0 || xx >= self.Size || yy >= self.Size {
+				continue
+			}
+			d := maxInt(absInt(dx), absInt(dy))
+			self.setFunction(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+func (self *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			self.setFunction(x + dx, y + dy, maxInt(absInt(dx), absInt(dy)) != 1)
+		}
+	}
+}
+
+func (self *Code) drawFunctionPatterns() {
+	size := self.Size
+
+	//timing patterns
+	for i := 0; i < size; i++ {
+		self.setFunction(6, i, i % 2 == 0)
+		self.setFunction(i, 6, i % 2 == 0)
+	}
+
+	self.drawFinder(3, 3)
+	self.drawFinder(size - 4, 3)
+	self.drawFinder(3, size - 4)
+
+	//alignment patterns, except where they would overlap a finder
+	pos := gAlignment[self.Version-1]
+	n := len(pos)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (i == 0 && j == 0) || (i == 0 && j == n - 1) || (i == n - 1 && j == 0) {
+				continue
+			}
+			self.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	//reserve the format area (drawn for real once the mask is chosen)
+	self.drawFormat(0)
+	self.drawVersion()
+}
+
+/*
+15 bit format information: level and mask protected by a BCH(15,5) code
+and xored with 101010000010010.
+*/
+func formatBits(level Level, mask int) int {
+	data := gLevelBits[level] << 3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data << 10 | rem) ^ 0x5412
+}
+
+/*
+18 bit version information (versions 7 and up): the version protected by
+a BCH(18,6) code.
+*/
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version << 12 | rem
+}
+
+func bit(value, i int) bool {
+	return (value >> uint(i)) & 1 == 1
+}
+
+func (self *Code) drawFormat(mask int) {
+	bits := formatBits(self.Level, mask)
+	size := self.Size
+
+	//first copy, around the top left finder
+	for i := 0; i <= 5; i++ {
+		self.setFunction(8, i, bit(bits, i))
+	}
+	self.setFunction(8, 7, bit(bits, 6))
+	self.setFunction(8, 8, bit(bits, 7))
+	self.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		self.setFunction(14 - i, 8, bit(bits, i))
+	}
+
+	//second copy, split between the other two finders
+	for i := 0; i < 8; i++ {
+		self.setFunction(size - 1 - i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		self.setFunction(8, size - 15 + i, bit(bits, i))
+	}
+
+	//always dark
+	self.setFunction(8, size - 8, true)
+}
+
+func (self *Code) drawVersion() {
+	if self.Version < 7 {
+		return
+	}
+
+	bits := versionBits(self.Version)
+	for i := 0; i < 18; i++ {
+		a := self.Size - 11 + i % 3
+		b := i / 3
+		self.setFunction(a, b, bit(bits, i))
+		self.setFunction(b, a, bit(bits, i))
+	}
+}
+
+/*
+Place the codewords in the zigzag order: two columns at a time from the
+right, alternately upwards and downwards, skipping the vertical timing
+pattern and function modules.
+*/
+func (self *Code) drawCodewords(codewords []byte) {
+	size := self.Size
+	i := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := 0; vert < size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				upward := (right + 1) & 2 == 0
+				y := vert
+				if upward {
+					y = size - 1 - vert
+				}
+
+				if !self.function[y][x] && i < len(codewords) * 8 {
+					self.modules[y][x] = (codewords[i / 8] >> uint(7 - i % 8)) & 1 == 1
+					i++
+				}
+				//any remainder bits stay light
+			}
+		}
+	}
+}
+
+func maskApplies(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x + y) % 2 == 0
+	case 1:
+		return y % 2 == 0
+	case 2:
+		return x % 3 == 0
+	case 3:
+		return (x + y) % 3 == 0
+	case 4:
+		return (x / 3 + y / 2) % 2 == 0
+	case 5:
+		return x * y % 2 + x * y % 3 == 0
+	case 6:
+		return (x * y % 2 + x * y % 3) % 2 == 0
+	default:
+		return ((x + y) % 2 + x * y % 3) % 2 == 0
+	}
+}
+
+//Xor the mask onto the data modules.  Applying it twice undoes it.
+func (self *Code) applyMask(mask int) {
+	for y := 0; y < self.Size; y++ {
+		for x := 0; x < self.Size; x++ {
+			if !self.function[y][x] && maskApplies(mask, x, y) {
+				self.modules[y][x] = !self.modules[y][x]
+			}
+		}
+	}
+}
+
+/*
+Encode text as a QR code with the given error correction level using the
+smallest version that fits.  Upper case letters, digits, space and
+$%*+-./: use the compact alphanumeric mode; anything else is stored as bytes.
+*/
+func Encode(text string, level Level) (*Code, error) {
+	if level < L || level > H {
+		return nil, errors.New("invalid error correction level")
+	}
+
+	version, data, err := encodeData(text, level)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCode(version, level)
+	c.drawFunctionPatterns()
+	c.drawCodewords(addErrorCorrection(data, version, level))
+
+	//choose the mask with the lowest penalty
+	best := -1
+	bestPenalty := 0
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		p := c.penalty()
+		if best < 0 || p < bestPenalty {
+			best = mask
+			bestPenalty = p
+		}
+		c.applyMask(mask)
+	}
+
+	c.Mask = best
+	c.applyMask(best)
+	c.drawFormat(best)
+
+	return c, nil
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,194 @@
+package qr
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"flag"
+	"io/ioutil"
+	"strings"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/")
+
+func Test_rs(t *testing.T) {
+	assert := assert.New(t)
+
+	//"HELLO WORLD" as 1-M (thonky.com QR code tutorial)
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ec := rsRemainder(data, rsGenerator(10))
+	assert.Equal([]byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ec)
+}
+
+func Test_encodeData(t *testing.T) {
+	assert := assert.New(t)
+
+	version, data, err := encodeData("HELLO WORLD", M)
+	assert.NoError(err)
+	assert.Equal(1, version)
+	assert.Equal([]byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}, data)
+
+	//byte mode: 0100 00000001 01100001 0000 then padding
+	version, data, err = encodeData("a", L)
+	assert.NoError(err)
+	assert.Equal(1, version)
+	assert.Equal([]byte{0x40, 0x16, 0x10, 0xEC, 0x11}, data[0:5])
+	assert.Equal(19, len(data))
+
+	//version grows with the text
+	version, _, err = encodeData(strings.Repeat("A", 26), M)
+	assert.NoError(err)
+	assert.Equal(2, version)
+
+	_, _, err = encodeData(strings.Repeat("x", 300), M)
+	assert.Error(err)
+}
+
+func Test_formatAndVersionBits(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0x77c4, formatBits(L, 0))  //111011111000100
+	assert.Equal(0x5412, formatBits(M, 0))  //101010000010010
+	assert.Equal(0x355f, formatBits(Q, 0))  //011010101011111
+	assert.Equal(0x1689, formatBits(H, 0))  //001011010001001
+	assert.Equal(0x662f, formatBits(L, 4))  //110011000101111
+	assert.Equal(0x07c94, versionBits(7))   //000111110010010100
+	assert.Equal(0x0a4d3, versionBits(10))  //001010010011010011
+}
+
+/*
+Read the codewords back out of a symbol: the inverse of placement and
+masking.  The format information is decoded from the first copy.
+*/
+func readBack(c *Code) (mask int, codewords []byte) {
+	format := 0
+	pos := [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8},
+		{5, 8}, {4, 8}, {3, 8}, {2, 8}, {1, 8}, {0, 8}}
+	for i, p := range pos {
+		if c.Black(p[0], p[1]) {
+			format |= 1 << uint(i)
+		}
+	}
+	for m := 0; m < 8; m++ {
+		if formatBits(c.Level, m) == format {
+			mask = m
+		}
+	}
+
+	c.applyMask(mask)
+	defer c.applyMask(mask)
+
+	var buf bitBuffer
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right + 1) & 2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.function[y][x] {
+					buf.bits = append(buf.bits, c.modules[y][x])
+				}
+			}
+		}
+	}
+
+	return mask, buf.bytes()
+}
+
+func Test_Encode(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, text := range []string{"HELLO WORLD", "A1 D1 U31 B36", "hub-any A1 D1 U31 B36",
+		strings.Repeat("Z9 ", 50), strings.Repeat("coordinates ", 9)} {
+		for level := L; level <= H; level++ {
+			c, err := Encode(text, level)
+			if !assert.NoError(err) {
+				continue
+			}
+
+			assert.Equal(c.Version * 4 + 17, c.Size)
+
+			version, data, _ := encodeData(text, level)
+			assert.Equal(version, c.Version)
+			expect := addErrorCorrection(data, version, level)
+
+			mask, codewords := readBack(c)
+			assert.Equal(c.Mask, mask)
+			assert.Equal(expect, codewords[0:len(expect)], text)
+
+			//the two format copies agree
+			for i := 0; i < 8; i++ {
+				assert.Equal(bit(formatBits(level, mask), i), c.Black(c.Size - 1 - i, 8))
+			}
+			assert.True(c.Black(8, c.Size - 8))
+		}
+	}
+
+	_, err := Encode("x", Level(4))
+	assert.Error(err)
+}
+
+func Test_addErrorCorrection(t *testing.T) {
+	assert := assert.New(t)
+
+	//5-Q has two blocks of 15 and two of 16 data codewords
+	data := make([]byte, 62)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	res := addErrorCorrection(data, 5, Q)
+	assert.Equal(134, len(res))
+	assert.Equal([]byte{0, 15, 30, 46, 1, 16, 31, 47}, res[0:8])
+	//the longer blocks contribute one more codeword at the end
+	assert.Equal([]byte{14, 29, 44, 60, 45, 61}, res[56:62])
+}
+
+func checkGolden(t *testing.T, name, got string) {
+	path := "testdata/" + name
+	if *updateGolden {
+		if err := ioutil.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, string(want), got, name)
+}
+
+//Rows of '#' (dark) and '.' (light)
+func modulesText(c *Code) string {
+	var sb strings.Builder
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Black(x, y) {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func Test_Golden(t *testing.T) {
+	c, err := Encode("A1 D1 U31 B36", M)
+	assert.NoError(t, err)
+	checkGolden(t, "coords.txt", modulesText(c))
+	checkGolden(t, "coords-halfblocks.txt", c.HalfBlocks(false))
+	checkGolden(t, "coords-halfblocks-paper.txt", c.HalfBlocks(true))
+
+	//version 7 has version information
+	c, err = Encode(strings.Repeat("hub-any ", 15), M)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, c.Version)
+	checkGolden(t, "version7.txt", modulesText(c))
+}
@@ -0,0 +1,131 @@
+package qr
+
+import (
+	"strings"
+)
+
+//Light border (in modules) required around the symbol
+const QuietZone = 4
+
+/*
+Penalty score of the masked symbol (ISO/IEC 18004 section 7.8.3).
+Lower is easier to scan.
+*/
+func (self *Code) penalty() int {
+	size := self.Size
+	res := 0
+
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return self.modules[x][y]
+		}
+		return self.modules[y][x]
+	}
+
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < size; y++ {
+			//runs of 5 or more modules of the same color
+			run := 1
+			for x := 1; x <= size; x++ {
+				if x < size && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					res += 3 + run - 5
+				}
+				run = 1
+			}
+
+			//finder-like patterns 1011101 with 4 light modules on one side
+			for x := 0; x + 7 <= size; x++ {
+				if !(at(x, y, vertical) && !at(x+1, y, vertical) && at(x+2, y, vertical) &&
+					at(x+3, y, vertical) && at(x+4, y, vertical) && !at(x+5, y, vertical) &&
+					at(x+6, y, vertical)) {
+					continue
+				}
+
+				lightBefore := x >= 4
+				for k := x - 4; k < x && lightBefore; k++ {
+					lightBefore = !at(k, y, vertical)
+				}
+				lightAfter := x + 11 <= size
+				for k := x + 7; k < x + 11 && lightAfter; k++ {
+					lightAfter = !at(k, y, vertical)
+				}
+
+				if lightBefore {
+					res += 40
+				}
+				if lightAfter {
+					res += 40
+				}
+			}
+		}
+	}
+
+	//2x2 blocks of the same color
+	for y := 0; y + 1 < size; y++ {
+		for x := 0; x + 1 < size; x++ {
+			c := self.modules[y][x]
+			if c == self.modules[y][x+1] && c == self.modules[y+1][x] && c == self.modules[y+1][x+1] {
+				res += 3
+			}
+		}
+	}
+
+	//balance of dark and light
+	dark := 0
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if self.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	percent := dark * 100 / (size * size)
+	res += absInt(percent - 50) / 5 * 10
+
+	return res
+}
+
+/*
+Draw the symbol with Unicode half blocks (two module rows per line),
+including the quiet zone.
+
+Terminals usually draw light text on a dark background so by default the
+blocks draw the light modules.  Set darkOnLight when printing on paper or a
+light background.
+*/
+func (self *Code) HalfBlocks(darkOnLight bool) string {
+	n := self.Size + 2 * QuietZone
+
+	//ink reports whether a character cell half should be filled
+	ink := func(x, y int) bool {
+		x -= QuietZone
+		y -= QuietZone
+		dark := x >= 0 && y >= 0 && x < self.Size && y < self.Size && self.modules[y][x]
+		return dark == darkOnLight
+	}
+
+	var sb strings.Builder
+	for y := 0; y < n; y += 2 {
+		for x := 0; x < n; x++ {
+			top := ink(x, y)
+			bottom := y + 1 < n && ink(x, y + 1)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
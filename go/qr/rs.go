@@ -0,0 +1,67 @@
+package qr
+
+/*
+Reed-Solomon error correction over GF(256) with the QR code polynomial
+x^8 + x^4 + x^3 + x^2 + 1 (0x11d).  The data is public so, unlike the
+shamir package, no care is taken against timing attacks.
+*/
+
+var gExp [256]byte
+var gLog [256]int
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gExp[i] = byte(x)
+		gLog[x] = i
+		x <<= 1
+		if x & 0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	gExp[255] = gExp[0]
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gExp[(gLog[a] + gLog[b]) % 255]
+}
+
+/*
+Coefficients of (x - a^0)(x - a^1)...(x - a^(degree-1)), highest power
+first, leading 1 omitted.
+*/
+func rsGenerator(degree int) []byte {
+	gen := make([]byte, degree)
+	gen[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		//multiply by (x - root)
+		for j := 0; j < degree; j++ {
+			gen[j] = gfMul(gen[j], root)
+			if j + 1 < degree {
+				gen[j] ^= gen[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+
+	return gen
+}
+
+//The error correction codewords: data * x^n mod generator
+func rsRemainder(data, gen []byte) []byte {
+	res := make([]byte, len(gen))
+	for _, b := range data {
+		factor := b ^ res[0]
+		copy(res, res[1:])
+		res[len(res)-1] = 0
+		for i := range res {
+			res[i] ^= gfMul(gen[i], factor)
+		}
+	}
+	return res
+}
@@ -0,0 +1,230 @@
+/*
+Shamir secret sharing over GF(256): split a secret (such as a coordinate
+password) into N shares so that any M of them recover it and fewer reveal
+nothing.
+
+Each byte of the secret is shared independently using a random polynomial
+of degree M-1 whose constant term is the secret byte.  Share x holds the
+polynomial values at x (1-255).
+*/
+package shamir
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"strconv"
+	"strings"
+)
+
+/*
+Multiply in GF(2^8) with the AES polynomial x^8 + x^4 + x^3 + x + 1.
+No table lookups or branches on the operands to avoid timing attacks.
+*/
+func gfMul(a, b byte) byte {
+	var res byte
+	for i := 0; i < 8; i++ {
+		//res ^= a if lowest bit of b is set
+		res ^= a & -(b & 1)
+		//a *= x (reduce if the high bit was set)
+		hi := a >> 7
+		a = (a << 1) ^ (0x1b & -hi)
+		b >>= 1
+	}
+	return res
+}
+
+//Multiplicative inverse: a^254.  gfInv(0) is 0.
+func gfInv(a byte) byte {
+	res := byte(1)
+	for i := 0; i < 7; i++ {
+		a = gfMul(a, a)
+		res = gfMul(res, a)
+	}
+	return res
+}
+
+/*
+One share of a secret.
+*/
+type Share struct {
+	//Number of shares needed to recover the secret
+	Threshold int
+
+	//Evaluation point (1-255).  Every share of a secret has a different X.
+	X byte
+
+	//Same length as the secret
+	Y []byte
+}
+
+/*
+Split the secret into n shares, any m of which can recover it.
+The random polynomial coefficients come from rng.
+*/
+func Split(secret []byte, m, n int, rng util.ByteSource) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret cannot be empty")
+	}
+
+	if m < 2 || m > n || n > 255 {
+		return nil, errors.New("need 2 <= m <= n <= 255")
+	}
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{
+			Threshold: m,
+			X: byte(i + 1),
+			Y: make([]byte, len(secret)),
+		}
+	}
+
+	coef := make([]byte, m)
+	defer util.Erase(coef)
+
+	for k, s := range secret {
+		coef[0] = s
+		for j := 1; j < m; j++ {
+			b, err := rng.NextByte()
+			if err != nil {
+				return nil, err
+			}
+			coef[j] = b
+		}
+
+		//Evaluate with Horner's method
+		for i := range shares {
+			var y byte
+			for j := m - 1; j >= 0; j-- {
+				y = gfMul(y, shares[i].X) ^ coef[j]
+			}
+			shares[i].Y[k] = y
+		}
+	}
+
+	return shares, nil
+}
+
+/*
+Recover the secret from at least Threshold shares.  Extra shares are ignored.
+*/
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares")
+	}
+
+	m := shares[0].Threshold
+	if m < 2 {
+		return nil, errors.New("invalid threshold")
+	}
+
+	seen := make(map[byte]bool)
+	var use []Share
+	for _, s := range shares {
+		if s.Threshold != m || len(s.Y) != len(shares[0].Y) {
+			return nil, errors.New("shares belong to different secrets")
+		}
+		if s.X == 0 {
+			return nil, errors.New("invalid share number 0")
+		}
+		if seen[s.X] {
+			continue
+		}
+		seen[s.X] = true
+		if len(use) < m {
+			use = append(use, s)
+		}
+	}
+
+	if len(use) < m {
+		return nil, fmt.Errorf("need %d different shares, have %d", m, len(use))
+	}
+
+	//Lagrange basis polynomials evaluated at 0
+	basis := make([]byte, m)
+	for i := range use {
+		num := byte(1)
+		den := byte(1)
+		for j := range use {
+			if i != j {
+				num = gfMul(num, use[j].X)
+				//subtraction is xor
+				den = gfMul(den, use[i].X ^ use[j].X)
+			}
+		}
+		basis[i] = gfMul(num, gfInv(den))
+	}
+
+	secret := make([]byte, len(use[0].Y))
+	for k := range secret {
+		var s byte
+		for i := range use {
+			s ^= gfMul(basis[i], use[i].Y[k])
+		}
+		secret[k] = s
+	}
+
+	return secret, nil
+}
+
+//The text which the checkword protects
+func (self *Share) body() string {
+	return fmt.Sprintf("%d-%d %s", self.Threshold, self.X, hex.EncodeToString(self.Y))
+}
+
+/*
+Printable form: "<threshold>-<x>", the value in groups of 4 hex digits and
+a checkword (see type1.CalcCheckword) to detect typos.
+Example: "2-1 3f2a 9b01 c7 ace"
+*/
+func (self *Share) String() string {
+	h := hex.EncodeToString(self.Y)
+	var groups []string
+	for len(h) > 4 {
+		groups = append(groups, h[0:4])
+		h = h[4:]
+	}
+	groups = append(groups, h)
+
+	return fmt.Sprintf("%d-%d %s %s", self.Threshold, self.X, strings.Join(groups, " "),
+		type1.CalcCheckword(self.body()))
+}
+
+/*
+Parse the output of Share.String().  Returns error if the checkword is wrong.
+*/
+func ParseShare(s string) (Share, error) {
+	var share Share
+
+	fields := strings.Fields(s)
+	if len(fields) < 3 {
+		return share, errors.New("share is too short")
+	}
+
+	header := strings.Split(fields[0], "-")
+	if len(header) != 2 {
+		return share, errors.New("share must begin with <threshold>-<number>")
+	}
+
+	m, err1 := strconv.Atoi(header[0])
+	x, err2 := strconv.Atoi(header[1])
+	if err1 != nil || err2 != nil || m < 2 || m > 255 || x < 1 || x > 255 {
+		return share, errors.New("share must begin with <threshold>-<number>")
+	}
+
+	y, err := hex.DecodeString(strings.ToLower(strings.Join(fields[1:len(fields)-1], "")))
+	if err != nil || len(y) == 0 {
+		return share, errors.New("share value must be hexadecimal")
+	}
+
+	share = Share{Threshold: m, X: byte(x), Y: y}
+
+	if !type1.IsCorrectCheckword(share.body(), fields[len(fields)-1]) {
+		return Share{}, errors.New("wrong checkword: the share has a typo")
+	}
+
+	return share, nil
+}
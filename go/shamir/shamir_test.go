@@ -0,0 +1,118 @@
+package shamir
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/cruxic/passillion/go/util"
+	"io"
+)
+
+func Test_gf(t *testing.T) {
+	assert := assert.New(t)
+
+	//FIPS-197 section 4.2
+	assert.Equal(byte(0xc1), gfMul(0x57, 0x83))
+	assert.Equal(byte(0xfe), gfMul(0x57, 0x13))
+	assert.Equal(byte(0), gfMul(0, 0x13))
+	assert.Equal(byte(0x13), gfMul(1, 0x13))
+
+	assert.Equal(byte(0), gfInv(0))
+	for a := 1; a < 256; a++ {
+		assert.Equal(byte(1), gfMul(byte(a), gfInv(byte(a))), a)
+		for b := 0; b < 256; b += 17 {
+			assert.Equal(gfMul(byte(a), byte(b)), gfMul(byte(b), byte(a)))
+		}
+	}
+}
+
+func Test_SplitCombine(t *testing.T) {
+	assert := assert.New(t)
+
+	secret := []byte("Correct Horse Battery ace")
+
+	rng := util.NewHmacCounterByteSource([]byte("test"), 1000)
+	shares, err := Split(secret, 3, 5, rng)
+	assert.NoError(err)
+	assert.Equal(5, len(shares))
+	for i, s := range shares {
+		assert.Equal(3, s.Threshold)
+		assert.Equal(byte(i + 1), s.X)
+		assert.Equal(len(secret), len(s.Y))
+		assert.NotEqual(secret, s.Y)
+	}
+
+	//every combination of 3
+	for a := 0; a < 5; a++ {
+		for b := a + 1; b < 5; b++ {
+			for c := b + 1; c < 5; c++ {
+				got, err := Combine([]Share{shares[c], shares[a], shares[b]})
+				assert.NoError(err)
+				assert.Equal(secret, got)
+			}
+		}
+	}
+
+	//all 5 (extras are ignored) and duplicates
+	got, err := Combine(shares)
+	assert.NoError(err)
+	assert.Equal(secret, got)
+
+	_, err = Combine([]Share{shares[0], shares[1], shares[1]})
+	assert.Equal("need 3 different shares, have 2", err.Error())
+
+	//shares of another split cannot be mixed in
+	other, _ := Split(secret, 2, 2, rng)
+	_, err = Combine([]Share{shares[0], shares[1], other[0]})
+	assert.Error(err)
+
+	_, err = Combine(nil)
+	assert.Error(err)
+
+	//bad parameters
+	_, err = Split(secret, 1, 3, rng)
+	assert.Error(err)
+	_, err = Split(secret, 4, 3, rng)
+	assert.Error(err)
+	_, err = Split(secret, 2, 256, rng)
+	assert.Error(err)
+	_, err = Split(nil, 2, 3, rng)
+	assert.Error(err)
+
+	//randomness exhausted
+	_, err = Split(secret, 2, 3, &util.FixedByteSource{Bytes: []byte{1, 2}})
+	assert.Equal(io.EOF, err)
+}
+
+func Test_SplitKnownAnswer(t *testing.T) {
+	assert := assert.New(t)
+
+	//f(x) = 0x42 + 0x03x
+	shares, err := Split([]byte{0x42}, 2, 3, &util.FixedByteSource{Bytes: []byte{3}})
+	assert.NoError(err)
+	assert.Equal([]byte{0x41}, shares[0].Y)
+	assert.Equal([]byte{0x44}, shares[1].Y)
+	assert.Equal([]byte{0x47}, shares[2].Y)
+}
+
+func Test_ShareString(t *testing.T) {
+	assert := assert.New(t)
+
+	share := Share{Threshold: 2, X: 3, Y: []byte{0x3f, 0x2a, 0x9b, 0x01, 0xc7}}
+	s := share.String()
+	assert.Equal("2-3 3f2a 9b01 c7 did", s)
+
+	parsed, err := ParseShare("  " + s + "\n")
+	assert.NoError(err)
+	assert.Equal(share, parsed)
+
+	//typo
+	_, err = ParseShare("2-3 3f2a 9b02 c7 " + s[len(s)-3:])
+	assert.Equal("wrong checkword: the share has a typo", err.Error())
+	_, err = ParseShare("2-2 3f2a 9b01 c7 " + s[len(s)-3:])
+	assert.Error(err)
+
+	for _, bad := range []string{"", "2-3 3f2a", "23 3f2a ace", "1-3 3f2a ace", "2-0 3f2a ace", "2-3 3g2a ace"} {
+		_, err = ParseShare(bad)
+		assert.Error(err, bad)
+	}
+}
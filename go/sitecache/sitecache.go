@@ -0,0 +1,259 @@
+/*
+An opt-in cache of SiteHash values so that looking up a site again does not
+cost several seconds of bcrypt.
+
+Entries are encrypted with AES-256-GCM under a key derived from the
+coordinate password by HKDF-SHA256, a fast KDF with its own domain label, so
+the cache key has nothing in common with the bcrypt input.  Entries are
+found by an HMAC of the site's salt (see type1.SiteId) so the file does not
+reveal the sitenames.  A wrong password derives different keys: it finds
+no entries and the entries it writes are never read with the right password.
+
+Note: the cache holds the result of the slow hash behind a fast one.  Anyone
+who copies the file can guess coordinate passwords much faster than by
+attacking bcrypt, so only enable it on a trusted machine.
+*/
+package sitecache
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"golang.org/x/crypto/hkdf"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const fileVersion = 1
+
+//HKDF info.  Change it if the key derivation or entry format changes.
+const domainLabel = "passillion-sitecache v1"
+
+const saltLen = 16
+
+const DefaultTTL = 30 * 24 * time.Hour
+
+type cacheEntry struct {
+	//Unix time after which the entry is ignored
+	Expires int64 `json:"expires"`
+	Nonce []byte `json:"nonce"`
+	Box []byte `json:"box"`
+}
+
+type cacheFile struct {
+	Version int `json:"version"`
+
+	//Random, created with the file
+	Salt []byte `json:"salt"`
+
+	//By entry ID (see entryId)
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+/*
+The cache file opened with one password and Options.
+*/
+type Cache struct {
+	path string
+	opt type1.Options
+	file cacheFile
+	aead cipher.AEAD
+	idKey []byte
+	rng util.ByteSource
+
+	//for testing
+	now func() time.Time
+}
+
+/*
+$XDG_CACHE_HOME/passillion/sitehashes.json (or the platform's equivalent).
+*/
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "passillion", "sitehashes.json"), nil
+}
+
+/*
+Open the cache at path (which need not exist yet) for the given coordinate
+password.  opt must be the same Options passed to CalcSiteHashWithOptions
+since the keyfile, pepper and mixed card ID change the hash.
+The password is not retained.
+*/
+func Open(path string, password []byte, opt type1.Options, rng util.ByteSource) (*Cache, error) {
+	c := &Cache{
+		path: path,
+		opt: opt,
+		rng: rng,
+		now: time.Now,
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err == nil {
+		if err = json.Unmarshal(content, &c.file); err != nil {
+			return nil, fmt.Errorf("%s is corrupt (use `passn cache clear`): %s", path, err.Error())
+		}
+		if c.file.Version != fileVersion || len(c.file.Salt) != saltLen {
+			return nil, fmt.Errorf("%s has an unsupported format (use `passn cache clear`)", path)
+		}
+	} else if os.IsNotExist(err) {
+		c.file.Version = fileVersion
+		c.file.Salt = make([]byte, saltLen)
+		if _, err = io.ReadFull(&util.ByteSourceReader{Source: rng}, c.file.Salt); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, err
+	}
+
+	if c.file.Entries == nil {
+		c.file.Entries = make(map[string]cacheEntry)
+	}
+
+	//A keyfile changes the hash so it must change the keys too.
+	info := []byte(domainLabel)
+	if len(opt.KeyfileDigest) > 0 {
+		info = append(info, "\nkeyfile "...)
+		info = append(info, opt.KeyfileDigest...)
+	}
+	defer util.Erase(info)
+
+	keys := make([]byte, 64)
+	defer util.Erase(keys)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, password, c.file.Salt, info), keys); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(keys[0:32])
+	if err != nil {
+		return nil, err
+	}
+	c.aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	c.idKey = append([]byte(nil), keys[32:64]...)
+
+	return c, nil
+}
+
+func (self *Cache) entryId(sitename, personalization string) string {
+	return hex.EncodeToString(util.HmacSha256(self.idKey, type1.SiteId(sitename, personalization, self.opt)))
+}
+
+//Binds the box to its entry so it cannot be moved to another site or given a later expiry.
+func additionalData(id string, expires int64) []byte {
+	ad := []byte(domainLabel + "\n" + id + "\n")
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(expires))
+	return append(ad, buf[:]...)
+}
+
+/*
+Find an unexpired entry.  Returns false if there is none or it does not
+decrypt (eg it was written by a different password or tampered with).
+*/
+func (self *Cache) Get(sitename, personalization string) (type1.SiteHash, bool) {
+	id := self.entryId(sitename, personalization)
+	entry, found := self.file.Entries[id]
+	if !found || entry.Expires <= self.now().Unix() || len(entry.Nonce) != self.aead.NonceSize() {
+		return nil, false
+	}
+
+	hash, err := self.aead.Open(nil, entry.Nonce, entry.Box, additionalData(id, entry.Expires))
+	if err != nil || len(hash) != sha256.Size {
+		return nil, false
+	}
+
+	return type1.SiteHash(hash), true
+}
+
+/*
+Add or replace an entry which expires after ttl.  Call Save() to write the file.
+*/
+func (self *Cache) Put(sitename, personalization string, hash type1.SiteHash, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	nonce := make([]byte, self.aead.NonceSize())
+	if _, err := io.ReadFull(&util.ByteSourceReader{Source: self.rng}, nonce); err != nil {
+		return err
+	}
+
+	id := self.entryId(sitename, personalization)
+	expires := self.now().Add(ttl).Unix()
+
+	self.file.Entries[id] = cacheEntry{
+		Expires: expires,
+		Nonce: nonce,
+		Box: self.aead.Seal(nil, nonce, []byte(hash), additionalData(id, expires)),
+	}
+
+	return nil
+}
+
+/*
+Write the file (mode 0600), dropping expired entries.  Entries written
+by other passwords are kept.
+*/
+func (self *Cache) Save() error {
+	now := self.now().Unix()
+	for id, entry := range self.file.Entries {
+		if entry.Expires <= now {
+			delete(self.file.Entries, id)
+		}
+	}
+
+	content, err := json.Marshal(&self.file)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(self.path), 0700); err != nil {
+		return err
+	}
+
+	//write then rename so a crash never leaves a partial file
+	tmp := self.path + ".tmp"
+	if err = ioutil.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, self.path)
+}
+
+//Forget the keys.  The Cache cannot be used afterwards.
+func (self *Cache) Erase() {
+	util.Erase(self.idKey)
+	self.aead = nil
+}
+
+/*
+Delete the cache file.  It is not an error if there is none.
+*/
+func Clear(path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package sitecache
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/cruxic/passillion/go/type1"
+	"github.com/cruxic/passillion/go/util"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+func Test_Cache(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "sitecache")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sub", "sitehashes.json")
+	rng := util.NewCryptoRandByteSource()
+	pass := []byte("Super Secretdog")
+	hash := type1.SiteHash(util.ByteSequence(0, 32))
+	hash2 := type1.SiteHash(util.ByteSequence(100, 32))
+
+	c, err := Open(path, pass, type1.Options{}, rng)
+	assert.NoError(err)
+
+	_, found := c.Get("example.com", "a")
+	assert.False(found)
+
+	assert.NoError(c.Put("example.com", "a", hash, time.Hour))
+	assert.NoError(c.Put("other.com", "", hash2, time.Hour))
+	assert.Error(c.Put("other.com", "", hash2, 0))
+
+	//normalized like the site hash
+	h, found := c.Get(" Example.COM", "A\n")
+	assert.True(found)
+	assert.Equal(hash, h)
+
+	assert.NoError(c.Save())
+	info, err := os.Stat(path)
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	//reopen with the same password
+	c, err = Open(path, pass, type1.Options{}, rng)
+	assert.NoError(err)
+	h, found = c.Get("example.com", "a")
+	assert.True(found)
+	assert.Equal(hash, h)
+	h, found = c.Get("other.com", "")
+	assert.True(found)
+	assert.Equal(hash2, h)
+	_, found = c.Get("example.com", "b")
+	assert.False(found)
+
+	//sitenames are not in the file
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(err)
+	assert.NotContains(string(content), "example")
+
+	//wrong password, keyfile, pepper or mixed card find nothing
+	wrong, err := Open(path, []byte("Super Secreudog"), type1.Options{}, rng)
+	assert.NoError(err)
+	_, found = wrong.Get("example.com", "a")
+	assert.False(found)
+
+	for _, opt := range []type1.Options{
+		{KeyfileDigest: make([]byte, 32)},
+		{Pepper: []byte("pepper")},
+		{CardId: "fog-ram", MixCardId: true},
+	} {
+		c2, err := Open(path, pass, opt, rng)
+		assert.NoError(err)
+		_, found = c2.Get("example.com", "a")
+		assert.False(found)
+	}
+
+	//a card ID which is only displayed does not matter
+	c2, err := Open(path, pass, type1.Options{CardId: "fog-ram"}, rng)
+	assert.NoError(err)
+	_, found = c2.Get("example.com", "a")
+	assert.True(found)
+
+	//a wrong password cannot poison the right password's entries
+	assert.NoError(wrong.Put("example.com", "a", hash2, time.Hour))
+	assert.NoError(wrong.Save())
+	c, err = Open(path, pass, type1.Options{}, rng)
+	assert.NoError(err)
+	h, found = c.Get("example.com", "a")
+	assert.True(found)
+	assert.Equal(hash, h)
+
+	//tampering: move a box to another entry
+	id1 := c.entryId("example.com", "a")
+	id2 := c.entryId("other.com", "")
+	c.file.Entries[id1] = c.file.Entries[id2]
+	_, found = c.Get("example.com", "a")
+	assert.False(found)
+
+	//tampering: extend the expiry
+	e := c.file.Entries[id2]
+	e.Expires += 3600
+	c.file.Entries[id2] = e
+	_, found = c.Get("other.com", "")
+	assert.False(found)
+}
+
+func Test_CacheExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "sitecache")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sitehashes.json")
+	rng := util.NewCryptoRandByteSource()
+	pass := []byte("Super Secretdog")
+	hash := type1.SiteHash(util.ByteSequence(0, 32))
+
+	now := time.Unix(1500000000, 0)
+	c, err := Open(path, pass, type1.Options{}, rng)
+	assert.NoError(err)
+	c.now = func() time.Time { return now }
+
+	assert.NoError(c.Put("example.com", "", hash, time.Hour))
+	assert.NoError(c.Put("other.com", "", hash, 2 * time.Hour))
+
+	now = now.Add(time.Hour - time.Second)
+	_, found := c.Get("example.com", "")
+	assert.True(found)
+
+	now = now.Add(time.Second)
+	_, found = c.Get("example.com", "")
+	assert.False(found)
+
+	//expired entries are dropped when saving
+	assert.NoError(c.Save())
+	assert.Equal(1, len(c.file.Entries))
+
+	assert.NoError(Clear(path))
+	_, err = os.Stat(path)
+	assert.True(os.IsNotExist(err))
+	assert.NoError(Clear(path))
+
+	//corrupt file
+	assert.NoError(ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = Open(path, pass, type1.Options{}, rng)
+	assert.Error(err)
+}
@@ -0,0 +1,65 @@
+package strength
+
+/*
+Frequently used passwords, most common first.  The position in the list is
+the rank used to estimate how quickly an attacker would guess it.
+*/
+var gCommonPasswords = []string{
+	"password", "123456", "12345678", "qwerty", "123456789", "12345", "1234",
+	"111111", "1234567", "dragon", "123123", "baseball", "abc123", "football",
+	"monkey", "letmein", "696969", "shadow", "master", "666666", "qwertyuiop",
+	"123321", "mustang", "1234567890", "michael", "654321", "superman",
+	"1qaz2wsx", "7777777", "121212", "000000", "qazwsx", "123qwe", "killer",
+	"trustno1", "jordan", "jennifer", "zxcvbnm", "asdfgh", "hunter", "buster",
+	"soccer", "harley", "batman", "andrew", "tigger", "sunshine", "iloveyou",
+	"2000", "charlie", "robert", "thomas", "hockey", "ranger", "daniel",
+	"starwars", "klaster", "112233", "george", "computer", "michelle",
+	"jessica", "pepper", "1111", "zxcvbn", "555555", "11111111", "131313",
+	"freedom", "777777", "pass", "maggie", "159753", "aaaaaa", "ginger",
+	"princess", "joshua", "cheese", "amanda", "summer", "love", "ashley",
+	"nicole", "chelsea", "biteme", "matthew", "access", "yankees", "987654321",
+	"dallas", "austin", "thunder", "taylor", "matrix", "mobilemail", "mom",
+	"monitor", "monitoring", "montana", "moon", "moscow", "welcome", "admin",
+	"passw0rd", "password1", "qwerty123", "secret", "login", "changeme",
+	"letmein1", "whatever", "starwars1", "solo", "hello", "flower",
+	"lovely", "passpass", "samsung", "google", "default", "azerty",
+}
+
+/*
+Common English words, roughly most frequent first.  Passphrases built from
+these are much weaker than their length suggests.
+*/
+var gCommonWords = []string{
+	"the", "be", "to", "of", "and", "in", "that", "have", "it", "for", "not",
+	"on", "with", "he", "as", "you", "do", "at", "this", "but", "his", "by",
+	"from", "they", "we", "say", "her", "she", "or", "an", "will", "my", "one",
+	"all", "would", "there", "their", "what", "so", "up", "out", "if", "about",
+	"who", "get", "which", "go", "me", "when", "make", "can", "like", "time",
+	"no", "just", "him", "know", "take", "people", "into", "year", "your",
+	"good", "some", "could", "them", "see", "other", "than", "then", "now",
+	"look", "only", "come", "its", "over", "think", "also", "back", "after",
+	"use", "two", "how", "our", "work", "first", "well", "way", "even", "new",
+	"want", "because", "any", "these", "give", "day", "most", "us", "is",
+	"are", "was", "were", "been", "has", "had", "did", "said", "very", "much",
+	"man", "woman", "child", "world", "life", "hand", "part", "place", "case",
+	"week", "company", "system", "program", "question", "government",
+	"number", "night", "point", "home", "water", "room", "mother", "father",
+	"area", "money", "story", "fact", "month", "lot", "right", "study", "book",
+	"eye", "job", "word", "business", "issue", "side", "kind", "head", "house",
+	"service", "friend", "power", "hour", "game", "line", "end", "member",
+	"law", "car", "city", "community", "name", "president", "team", "minute",
+	"idea", "kid", "body", "information", "school", "face", "others", "level",
+	"office", "door", "health", "person", "art", "war", "history", "party",
+	"result", "change", "morning", "reason", "research", "girl", "guy",
+	"moment", "air", "teacher", "force", "education", "dog", "cat", "red",
+	"blue", "green", "black", "white", "yellow", "orange", "purple", "pink",
+	"happy", "sun", "star", "sky", "tree", "fish", "bird", "horse", "apple",
+	"banana", "cherry", "summer", "winter", "spring", "autumn", "fall",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday",
+	"sunday", "january", "february", "march", "april", "may", "june", "july",
+	"august", "september", "october", "november", "december", "love",
+	"secret", "super", "password", "correct", "battery", "staple", "hello",
+	"world", "dragon", "master", "monkey", "baby", "angel", "magic", "music",
+	"family", "garden", "river", "ocean", "mountain", "island", "forest",
+	"little", "big", "small", "great", "old", "young", "long", "high", "best",
+}
@@ -0,0 +1,671 @@
+/*
+Estimate how many guesses an attacker needs to find a password.
+
+The estimate is the cheapest way to describe the password as a sequence of
+patterns (dictionary words, keyboard walks, repetitions, sequences and dates)
+with the remaining characters brute forced.  It is deliberately pessimistic:
+a human chosen password is assumed to be as weak as its weakest explanation.
+*/
+package strength
+
+import (
+	"github.com/cruxic/passillion/go/wordlist"
+	"math"
+	"strings"
+	"unicode"
+)
+
+//Names used in Match.Pattern
+const (
+	PatternDictionary = "dictionary"
+	PatternKeyboard = "keyboard"
+	PatternRepeat = "repeat"
+	PatternSequence = "sequence"
+	PatternDate = "date"
+	PatternSeparator = "separator"
+	PatternBruteForce = "bruteforce"
+)
+
+/*
+Only this many runes are searched for patterns; the rest is brute forced.
+Some searches grow with the cube of the length and the tui estimates on
+every keystroke.
+*/
+const MaxAnalyzedLen = 100
+
+//Characters commonly put between the words of a passphrase
+const gSeparators = " -_."
+
+/*
+A portion of the password explained by a pattern.  The matched text itself
+is intentionally not stored so that results can be displayed safely.
+*/
+type Match struct {
+	Pattern string
+
+	//Rune offsets [Start, End) within the password
+	Start, End int
+
+	//Entropy of this portion
+	Bits float64
+}
+
+type Result struct {
+	//Estimated entropy of the whole password
+	Bits float64
+
+	//The patterns which make up the cheapest explanation of the password,
+	// in order.
+	Matches []Match
+}
+
+/*
+Return the distinct pattern names found in the result (excluding brute force).
+*/
+func (self *Result) Weaknesses() []string {
+	var res []string
+	seen := make(map[string]bool)
+	for _, m := range self.Matches {
+		if m.Pattern != PatternBruteForce && m.Pattern != PatternSeparator && !seen[m.Pattern] {
+			seen[m.Pattern] = true
+			res = append(res, m.Pattern)
+		}
+	}
+	return res
+}
+
+var gRankedDictionaries []map[string]int
+
+//Longest word in gRankedDictionaries
+var gMaxWordLen int
+
+func addDictionary(words []string, rank func(i int) int) {
+	m := make(map[string]int, len(words))
+	for i, word := range words {
+		if _, exists := m[word]; !exists {
+			m[word] = rank(i)
+		}
+		if n := len([]rune(word)); n > gMaxWordLen {
+			gMaxWordLen = n
+		}
+	}
+	gRankedDictionaries = append(gRankedDictionaries, m)
+}
+
+func init() {
+	for _, list := range [][]string{gCommonPasswords, gCommonWords} {
+		addDictionary(list, func(i int) int {
+			return i + 1
+		})
+	}
+
+	//The bundled lists are for random words (eg passn genpass) so every
+	// word is as likely as any other: the rank is the size of the list.
+	for _, name := range []string{wordlist.Standard, wordlist.Aspell4} {
+		list, err := wordlist.Get(name)
+		if err != nil {
+			panic(err)
+		}
+		addDictionary(list, func(i int) int {
+			return len(list)
+		})
+	}
+}
+
+func log2(x float64) float64 {
+	return math.Log2(x)
+}
+
+/*
+Entropy per character if an attacker brute forces using every class
+of character that appears in the password.
+*/
+func bruteForceBitsPerChar(password []rune) float64 {
+	var lower, upper, digit, symbol, other bool
+	for _, c := range password {
+		switch {
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= '0' && c <= '9':
+			digit = true
+		case c >= ' ' && c <= '~':
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	cardinality := 0
+	if lower {
+		cardinality += 26
+	}
+	if upper {
+		cardinality += 26
+	}
+	if digit {
+		cardinality += 10
+	}
+	if symbol {
+		cardinality += 33
+	}
+	if other {
+		cardinality += 100
+	}
+
+	if cardinality == 0 {
+		return 0
+	}
+
+	return log2(float64(cardinality))
+}
+
+func nCk(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	res := 1.0
+	for i := 1; i <= k; i++ {
+		res *= float64(n - k + i)
+		res /= float64(i)
+	}
+	return res
+}
+
+/*
+Extra bits needed to guess the capitalization of a word.  Capitalizing
+only the first letter or the whole word costs just 1 bit.
+*/
+func uppercaseBits(word []rune) float64 {
+	upper, lower := 0, 0
+	for _, c := range word {
+		if c >= 'A' && c <= 'Z' {
+			upper++
+		} else if c >= 'a' && c <= 'z' {
+			lower++
+		}
+	}
+
+	if upper == 0 {
+		return 0
+	}
+
+	first := word[0] >= 'A' && word[0] <= 'Z'
+	if lower == 0 || (first && upper == 1) {
+		return 1
+	}
+
+	var variations float64
+	for i := 1; i <= upper && i <= lower; i++ {
+		variations += nCk(upper + lower, i)
+	}
+	return log2(variations)
+}
+
+//Common character substitutions ("p4ssw0rd")
+var gLeet = map[rune]rune{
+	'4': 'a', '@': 'a', '3': 'e', '1': 'i', '!': 'i', '0': 'o', '$': 's',
+	'5': 's', '7': 't', '+': 't',
+}
+
+func matchDictionary(password []rune, fn func(Match)) {
+	n := len(password)
+	for i := 0; i < n; i++ {
+		for j := i + 3; j <= n && j - i <= gMaxWordLen; j++ {
+			token := password[i:j]
+
+			//un-leet and lower case
+			plain := make([]rune, len(token))
+			substitutions := 0
+			for k, c := range token {
+				if c >= 'A' && c <= 'Z' {
+					c += 'a' - 'A'
+				} else if r, ok := gLeet[c]; ok {
+					c = r
+					substitutions++
+				}
+				plain[k] = c
+			}
+
+			for _, dict := range gRankedDictionaries {
+				rank, ok := dict[string(plain)]
+				if !ok && substitutions > 0 {
+					//"1" is also commonly used for "l"
+					rank, ok = dict[strings.Replace(string(plain), "i", "l", -1)]
+				}
+
+				if ok {
+					fn(Match{
+						Pattern: PatternDictionary,
+						Start: i,
+						End: j,
+						Bits: log2(float64(rank)) + uppercaseBits(token) + float64(substitutions),
+					})
+				}
+			}
+		}
+	}
+}
+
+//QWERTY keyboard rows (unshifted, shifted) for keyboard walk detection.
+var gKeyboardRows = [][2]string{
+	{"`1234567890-=", "~!@#$%^&*()_+"},
+	{"qwertyuiop[]\\", "QWERTYUIOP{}|"},
+	{"asdfghjkl;'", "ASDFGHJKL:\""},
+	{"zxcvbnm,./", "ZXCVBNM<>?"},
+}
+
+type keyPos struct {
+	row, col int
+	shifted bool
+}
+
+var gKeyPositions map[rune]keyPos
+
+func init() {
+	gKeyPositions = make(map[rune]keyPos)
+	for r, row := range gKeyboardRows {
+		for shift := 0; shift < 2; shift++ {
+			for c, key := range []rune(row[shift]) {
+				gKeyPositions[key] = keyPos{row: r, col: c, shifted: shift == 1}
+			}
+		}
+	}
+}
+
+/*
+Return the direction from key a to neighboring key b (1-6) or 0 if
+the keys are not adjacent.  Rows are staggered half a key to the right.
+*/
+func keyDirection(a, b rune) int {
+	pa, oka := gKeyPositions[a]
+	pb, okb := gKeyPositions[b]
+	if !oka || !okb {
+		return 0
+	}
+
+	dr := pb.row - pa.row
+	dc := pb.col - pa.col
+	switch {
+	case dr == 0 && dc == -1:
+		return 1
+	case dr == 0 && dc == 1:
+		return 2
+	case dr == -1 && dc == 0:
+		return 3
+	case dr == -1 && dc == 1:
+		return 4
+	case dr == 1 && dc == -1:
+		return 5
+	case dr == 1 && dc == 0:
+		return 6
+	}
+	return 0
+}
+
+func matchKeyboard(password []rune, fn func(Match)) {
+	//number of keys and average number of neighbors
+	const startingKeys = 47
+	const avgDegree = 4.6
+
+	n := len(password)
+	i := 0
+	for i < n-2 {
+		j := i + 1
+		turns := 0
+		shifted := 0
+		lastDir := 0
+		for j < n {
+			dir := keyDirection(password[j-1], password[j])
+			if dir == 0 {
+				break
+			}
+			if dir != lastDir {
+				turns++
+				lastDir = dir
+			}
+			j++
+		}
+
+		if j - i >= 3 {
+			for _, c := range password[i:j] {
+				if gKeyPositions[c].shifted {
+					shifted++
+				}
+			}
+
+			length := j - i
+			bits := log2(startingKeys) + log2(float64(length)) + float64(turns) * log2(avgDegree)
+			if shifted > 0 && shifted < length {
+				bits += log2(nCk(length, shifted))
+			} else if shifted == length {
+				bits += 1
+			}
+
+			fn(Match{Pattern: PatternKeyboard, Start: i, End: j, Bits: bits})
+			i = j - 1
+		} else {
+			i++
+		}
+	}
+}
+
+//Runs like "abcd", "9876" or "XYZ".
+func matchSequence(password []rune, fn func(Match)) {
+	class := func(c rune) int {
+		switch {
+		case c >= 'a' && c <= 'z':
+			return 1
+		case c >= 'A' && c <= 'Z':
+			return 2
+		case c >= '0' && c <= '9':
+			return 3
+		}
+		return 0
+	}
+
+	n := len(password)
+	i := 0
+	for i < n-2 {
+		delta := password[i+1] - password[i]
+		j := i + 1
+		if (delta == 1 || delta == -1) && class(password[i]) != 0 {
+			for j < n && class(password[j]) == class(password[i]) && password[j] - password[j-1] == delta {
+				j++
+			}
+		}
+
+		if j - i >= 3 {
+			var base float64
+			first := password[i]
+			if strings.ContainsRune("aAzZ01", first) || first == '9' {
+				base = 4
+			} else if class(first) == 3 {
+				base = 10
+			} else {
+				base = 26
+			}
+
+			bits := log2(base) + log2(float64(j - i))
+			if delta < 0 {
+				bits += 1
+			}
+
+			fn(Match{Pattern: PatternSequence, Start: i, End: j, Bits: bits})
+			i = j - 1
+		} else {
+			i++
+		}
+	}
+}
+
+func runesEqual(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+A single separator between two letters, as in "correct horse".  An attacker
+trying passphrases only has to guess which of a few common separators was
+used, not any printable character.
+*/
+func matchSeparator(password []rune, fn func(Match)) {
+	for i := 1; i + 1 < len(password); i++ {
+		if strings.ContainsRune(gSeparators, password[i]) &&
+			unicode.IsLetter(password[i-1]) && unicode.IsLetter(password[i+1]) {
+			fn(Match{
+				Pattern: PatternSeparator,
+				Start: i,
+				End: i + 1,
+				Bits: log2(float64(len(gSeparators))),
+			})
+		}
+	}
+}
+
+//Repeated characters or substrings like "aaaa" or "abcabcabc".
+func matchRepeat(password []rune, fn func(Match)) {
+	n := len(password)
+	for i := 0; i < n; i++ {
+		bestEnd := -1
+		bestUnit := 0
+		bestCount := 0
+		for unit := 1; i + unit * 2 <= n; unit++ {
+			count := 1
+			for i + (count + 1) * unit <= n &&
+				runesEqual(password[i + count * unit:i + (count + 1) * unit], password[i:i + unit]) {
+				count++
+			}
+
+			end := i + count * unit
+			if count >= 2 && end > bestEnd {
+				bestEnd = end
+				bestUnit = unit
+				bestCount = count
+			}
+		}
+
+		if bestEnd > 0 {
+			unitBits := EstimateRunes(password[i:i + bestUnit]).Bits
+			fn(Match{
+				Pattern: PatternRepeat,
+				Start: i,
+				End: bestEnd,
+				Bits: unitBits + log2(float64(bestCount)),
+			})
+		}
+	}
+}
+
+func atoi(digits []rune) int {
+	v := 0
+	for _, d := range digits {
+		v = v * 10 + int(d - '0')
+	}
+	return v
+}
+
+func isDigits(s []rune) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+func validYear(y int, nDigits int) bool {
+	if nDigits == 2 {
+		return true
+	}
+	return nDigits == 4 && y >= 1900 && y <= 2099
+}
+
+func validDayMonth(d, m int) bool {
+	return d >= 1 && d <= 31 && m >= 1 && m <= 12
+}
+
+/*
+Return true if the parts can be read as a date in day-month-year,
+month-day-year or year-month-day order.
+*/
+func isDate(parts [][]rune) bool {
+	if len(parts) != 3 {
+		return false
+	}
+
+	a, b, c := atoi(parts[0]), atoi(parts[1]), atoi(parts[2])
+	la, lb, lc := len(parts[0]), len(parts[1]), len(parts[2])
+
+	if lb > 2 {
+		return false
+	}
+
+	if la <= 2 && validYear(c, lc) && (validDayMonth(a, b) || validDayMonth(b, a)) {
+		return true
+	}
+
+	if lc <= 2 && validYear(a, la) && validDayMonth(c, b) {
+		return true
+	}
+
+	return false
+}
+
+//Years (1900-2099) and dates with or without separators.
+func matchDate(password []rune, fn func(Match)) {
+	//119 years, 365 days
+	yearBits := log2(119)
+	dateBits := log2(119 * 365)
+
+	n := len(password)
+	for i := 0; i < n; i++ {
+		for j := i + 4; j <= n && j <= i + 10; j++ {
+			token := password[i:j]
+
+			if len(token) == 4 && isDigits(token) {
+				y := atoi(token)
+				if y >= 1900 && y <= 2099 {
+					fn(Match{Pattern: PatternDate, Start: i, End: j, Bits: yearBits})
+				}
+			}
+
+			if isDigits(token) {
+				//no separators: try each way of splitting into 3 parts
+				found := false
+				for x := 1; x <= 4 && !found; x++ {
+					for y := x + 1; y <= x + 2 && y < len(token) && !found; y++ {
+						if isDate([][]rune{token[:x], token[x:y], token[y:]}) {
+							found = true
+						}
+					}
+				}
+				if found && len(token) >= 6 {
+					fn(Match{Pattern: PatternDate, Start: i, End: j, Bits: dateBits})
+				}
+				continue
+			}
+
+			//with separators
+			sep := token[len(token) - 1]
+			for _, c := range token {
+				if !(c >= '0' && c <= '9') {
+					sep = c
+					break
+				}
+			}
+
+			if !strings.ContainsRune("/-._ ", sep) {
+				continue
+			}
+
+			strParts := strings.Split(string(token), string(sep))
+			parts := make([][]rune, len(strParts))
+			ok := len(parts) == 3
+			for k, p := range strParts {
+				parts[k] = []rune(p)
+				if !isDigits(parts[k]) {
+					ok = false
+				}
+			}
+
+			if ok && isDate(parts) {
+				fn(Match{Pattern: PatternDate, Start: i, End: j, Bits: dateBits + 2})
+			}
+		}
+	}
+}
+
+/*
+Estimate the entropy, in bits, of a human chosen password.
+*/
+func Estimate(password string) Result {
+	return EstimateRunes([]rune(password))
+}
+
+/*
+Estimate for a password held in a rune slice (eg an input field) so the
+caller need not make a string copy of it.  The dictionary lookups still
+convert short substrings.
+*/
+func EstimateRunes(password []rune) Result {
+	runes := password
+	if len(runes) > MaxAnalyzedLen {
+		runes = runes[:MaxAnalyzedLen]
+	}
+	n := len(runes)
+
+	//All candidate matches grouped by the position where they end
+	byEnd := make([][]Match, n + 1)
+	add := func(m Match) {
+		byEnd[m.End] = append(byEnd[m.End], m)
+	}
+
+	matchDictionary(runes, add)
+	matchKeyboard(runes, add)
+	matchSequence(runes, add)
+	matchRepeat(runes, add)
+	matchDate(runes, add)
+	matchSeparator(runes, add)
+
+	//Find the cheapest explanation of every prefix (dynamic programming)
+	perChar := bruteForceBitsPerChar(password)
+	bits := make([]float64, n + 1)
+	last := make([]Match, n + 1)
+
+	for j := 1; j <= n; j++ {
+		bits[j] = bits[j-1] + perChar
+		last[j] = Match{Pattern: PatternBruteForce, Start: j - 1, End: j, Bits: perChar}
+
+		for _, m := range byEnd[j] {
+			if b := bits[m.Start] + m.Bits; b < bits[j] {
+				bits[j] = b
+				last[j] = m
+			}
+		}
+	}
+
+	//Walk back to collect the chosen matches, merging brute force runs
+	var matches []Match
+	for j := n; j > 0; j = last[j].Start {
+		m := last[j]
+		k := len(matches) - 1
+		if m.Pattern == PatternBruteForce && k >= 0 && matches[k].Pattern == PatternBruteForce {
+			matches[k].Start = m.Start
+			matches[k].Bits += m.Bits
+		} else {
+			matches = append(matches, m)
+		}
+	}
+
+	for a, b := 0, len(matches) - 1; a < b; a, b = a + 1, b - 1 {
+		matches[a], matches[b] = matches[b], matches[a]
+	}
+
+	total := bits[n]
+	if rest := len(password) - n; rest > 0 {
+		//beyond MaxAnalyzedLen
+		m := Match{Pattern: PatternBruteForce, Start: n, End: len(password), Bits: float64(rest) * perChar}
+		k := len(matches) - 1
+		if k >= 0 && matches[k].Pattern == PatternBruteForce {
+			matches[k].End = m.End
+			matches[k].Bits += m.Bits
+		} else {
+			matches = append(matches, m)
+		}
+		total += m.Bits
+	}
+
+	return Result{
+		Bits: total,
+		Matches: matches,
+	}
+}
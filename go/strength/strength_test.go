@@ -0,0 +1,146 @@
+package strength
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"time"
+)
+
+func patterns(r Result) []string {
+	res := make([]string, len(r.Matches))
+	for i, m := range r.Matches {
+		res[i] = m.Pattern
+	}
+	return res
+}
+
+func Test_Estimate_repeat(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Estimate("aaaaaaaaaa")
+	assert.Equal([]string{PatternRepeat}, patterns(r))
+	assert.InDelta(8.02, r.Bits, 0.01)  //log2(26) + log2(10)
+
+	r = Estimate("abcXabcXabcX")
+	assert.Equal([]string{PatternRepeat}, patterns(r))
+	assert.True(r.Bits < 20)
+}
+
+func Test_Estimate_dictionary(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Estimate("password")
+	assert.Equal([]string{PatternDictionary}, patterns(r))
+	assert.Equal(0.0, r.Bits)  //rank 1
+
+	//capitalized and leet
+	r = Estimate("P4ssw0rd")
+	assert.Equal([]string{PatternDictionary}, patterns(r))
+	assert.InDelta(3.0, r.Bits, 0.01)
+
+	r = Estimate("correcthorsebatterystaple")
+	assert.Equal([]string{PatternDictionary, PatternDictionary, PatternDictionary, PatternDictionary}, patterns(r))
+	assert.True(r.Bits < 40)
+}
+
+func Test_Estimate_passphrase(t *testing.T) {
+	assert := assert.New(t)
+
+	//separators cost a few bits, not a brute forced character each
+	r := Estimate("correct horse battery staple")
+	assert.Equal([]string{PatternDictionary, PatternSeparator, PatternDictionary, PatternSeparator,
+		PatternDictionary, PatternSeparator, PatternDictionary}, patterns(r))
+	assert.InDelta(Estimate("correcthorsebatterystaple").Bits + 3 * 2, r.Bits, 0.01)
+	assert.True(r.Bits < 40)
+	assert.Equal([]string{PatternDictionary}, r.Weaknesses())
+
+	//random words from a bundled list: log2(1631) bits each
+	r = Estimate("twin dawn greg vote bade")
+	assert.Equal(9, len(r.Matches))
+	assert.InDelta(5 * 10.67 + 4 * 2, r.Bits, 0.1)
+
+	r = Estimate("Oath-Zest-Vial")
+	assert.Equal(5, len(r.Matches))
+	assert.True(r.Bits < 45)
+
+	//not between letters
+	r = Estimate("Qm7# vT2")
+	assert.Equal([]string{PatternBruteForce}, patterns(r))
+}
+
+func Test_Estimate_long(t *testing.T) {
+	assert := assert.New(t)
+
+	//only MaxAnalyzedLen runes are searched; the rest is brute forced
+	long := strings.Repeat("ab1!Xq", 180)
+	start := time.Now()
+	r := Estimate(long)
+	assert.True(time.Since(start) < time.Second)
+
+	head := Estimate(long[:MaxAnalyzedLen])
+	assert.InDelta(head.Bits + float64(len(long) - MaxAnalyzedLen) * log2(95), r.Bits, 0.01)
+	assert.Equal(len(long), r.Matches[len(r.Matches)-1].End)
+}
+
+func Test_Estimate_keyboard(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Estimate("zxcvfrtgbnhy")
+	assert.Equal([]string{PatternKeyboard}, patterns(r))
+	assert.True(r.Bits < 30)
+
+	assert.Equal(2, keyDirection('g', 'h'))
+	assert.Equal(4, keyDirection('g', 'y'))
+	assert.Equal(0, keyDirection('g', 'p'))
+}
+
+func Test_Estimate_sequence(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Estimate("abcdefghij")
+	assert.Equal([]string{PatternSequence}, patterns(r))
+	assert.InDelta(2 + 3.32, r.Bits, 0.01)
+
+	r = Estimate("9876543")
+	assert.Equal([]string{PatternSequence}, patterns(r))
+}
+
+func Test_Estimate_date(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Estimate("14/07/1989")
+	assert.Equal([]string{PatternDate}, patterns(r))
+	assert.InDelta(17.4, r.Bits, 0.1)
+
+	r = Estimate("19890714")
+	assert.Equal([]string{PatternDate}, patterns(r))
+
+	assert.False(isDate([][]rune{[]rune("40"), []rune("13"), []rune("1989")}))
+}
+
+func Test_Estimate_random(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Estimate("")
+	assert.Equal(0.0, r.Bits)
+	assert.Nil(r.Matches)
+
+	//nothing to find here
+	r = Estimate("Qm7#vT2p9Lx!")
+	assert.Equal([]string{PatternBruteForce}, patterns(r))
+	assert.InDelta(12 * 6.57, r.Bits, 0.1)
+	assert.Nil(r.Weaknesses())
+
+	r = Estimate("kjqwpxz Monkey 1989")
+	assert.Equal([]string{PatternDictionary, PatternDate}, r.Weaknesses())
+}
+
+func Test_uppercaseBits(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0.0, uppercaseBits([]rune("hello")))
+	assert.Equal(1.0, uppercaseBits([]rune("Hello")))
+	assert.Equal(1.0, uppercaseBits([]rune("HELLO")))
+	assert.InDelta(3.9, uppercaseBits([]rune("hElLo")), 0.1)  //log2(5 + 10)
+}
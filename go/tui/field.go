@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"unicode/utf8"
+)
+
+/*
+A single line text input.
+*/
+type Field struct {
+	Label string
+
+	//Display '*' instead of the text
+	Masked bool
+
+	text []rune
+	cursor int
+}
+
+func (self *Field) Text() string {
+	return string(self.text)
+}
+
+/*
+The text without a copy, eg to check a password.  Only valid until the next
+edit and must not be modified.
+*/
+func (self *Field) Runes() []rune {
+	return self.text
+}
+
+/*
+The text as UTF-8 in a new slice which the caller must erase.  Unlike
+[]byte(Text()) no string copy (which cannot be erased) is made.
+*/
+func (self *Field) Bytes() []byte {
+	n := 0
+	for _, r := range self.text {
+		if size := utf8.RuneLen(r); size > 0 {
+			n += size
+		} else {
+			n += utf8.RuneLen(utf8.RuneError)  //what EncodeRune writes
+		}
+	}
+
+	raw := make([]byte, n)
+	i := 0
+	for _, r := range self.text {
+		i += utf8.EncodeRune(raw[i:], r)
+	}
+	return raw
+}
+
+func (self *Field) Len() int {
+	return len(self.text)
+}
+
+//Replace the text and move the cursor to the end.
+func (self *Field) SetText(s string) {
+	self.Erase()
+	self.text = []rune(s)
+	self.cursor = len(self.text)
+}
+
+//Cursor position in runes.
+func (self *Field) Cursor() int {
+	return self.cursor
+}
+
+/*
+The text to draw: the text itself or one '*' per character when Masked.
+*/
+func (self *Field) Display() string {
+	if !self.Masked {
+		return string(self.text)
+	}
+
+	stars := make([]byte, len(self.text))
+	for i := range stars {
+		stars[i] = '*'
+	}
+	return string(stars)
+}
+
+/*
+Apply an editing key.  Returns false if the key is not an editing key
+(eg Enter or Tab) so the caller can handle it.
+*/
+func (self *Field) HandleKey(key Key) bool {
+	switch key.Code {
+	case KeyRune:
+		self.insert(key.Rune)
+	case KeyBackspace:
+		if self.cursor > 0 {
+			self.remove(self.cursor - 1)
+			self.cursor--
+		}
+	case KeyDelete:
+		if self.cursor < len(self.text) {
+			self.remove(self.cursor)
+		}
+	case KeyLeft:
+		if self.cursor > 0 {
+			self.cursor--
+		}
+	case KeyRight:
+		if self.cursor < len(self.text) {
+			self.cursor++
+		}
+	case KeyHome:
+		self.cursor = 0
+	case KeyEnd:
+		self.cursor = len(self.text)
+	case KeyCtrlU:
+		self.Erase()
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (self *Field) insert(r rune) {
+	if len(self.text) == cap(self.text) {
+		//grow by hand so the old array can be erased
+		bigger := make([]rune, len(self.text), 2 * cap(self.text) + 32)
+		copy(bigger, self.text)
+		cursor := self.cursor
+		self.Erase()
+		self.text = bigger
+		self.cursor = cursor
+	}
+
+	self.text = self.text[:len(self.text)+1]
+	copy(self.text[self.cursor+1:], self.text[self.cursor:])
+	self.text[self.cursor] = r
+	self.cursor++
+}
+
+func (self *Field) remove(i int) {
+	copy(self.text[i:], self.text[i+1:])
+	self.text[len(self.text)-1] = 0  //don't leave a copy of the last character
+	self.text = self.text[:len(self.text)-1]
+}
+
+/*
+Overwrite the text (eg a password) and empty the field.
+*/
+func (self *Field) Erase() {
+	full := self.text[:cap(self.text)]
+	for i := range full {
+		full[i] = 0
+	}
+	self.text = self.text[:0]
+	self.cursor = 0
+}
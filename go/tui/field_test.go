@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func typeKeys(f *Field, s string) {
+	for _, key := range ParseKeys([]byte(s)) {
+		f.HandleKey(key)
+	}
+}
+
+func Test_Field(t *testing.T) {
+	assert := assert.New(t)
+
+	f := &Field{}
+	typeKeys(f, "helo")
+	assert.Equal("helo", f.Text())
+	assert.Equal(4, f.Cursor())
+
+	//insert in the middle
+	typeKeys(f, "\x1b[D\x1b[Dl")
+	assert.Equal("hello", f.Text())
+	assert.Equal(3, f.Cursor())
+
+	//backspace and delete
+	typeKeys(f, "\x7f\x1b[3~")
+	assert.Equal("heo", f.Text())
+	assert.Equal(2, f.Cursor())
+
+	//home, end and the edges
+	typeKeys(f, "\x1b[1~\x7f\x1b[DX")
+	assert.Equal("Xheo", f.Text())
+	typeKeys(f, "\x1b[4~\x1b[3~\x1b[CY")
+	assert.Equal("XheoY", f.Text())
+	assert.Equal(5, f.Len())
+
+	//not editing keys
+	assert.False(f.HandleKey(Key{Code: KeyEnter}))
+	assert.False(f.HandleKey(Key{Code: KeyTab}))
+	assert.False(f.HandleKey(Key{Code: KeyUp}))
+
+	typeKeys(f, "\x15")
+	assert.Equal("", f.Text())
+	assert.Equal(0, f.Cursor())
+
+	f.SetText("example.com")
+	assert.Equal(11, f.Cursor())
+	assert.Equal("example.com", f.Display())
+
+	//grows past its capacity and keeps the cursor
+	f.SetText("")
+	long := "abcdefghijklmnopqrstuvwxyz0123456789abcdefghijklmnopqrstuvwxyz"
+	typeKeys(f, long[:30] + "\x1b[D" + long[30:])
+	assert.Equal(long[:29] + long[30:] + long[29:30], f.Text())
+}
+
+func Test_FieldMaskedErase(t *testing.T) {
+	assert := assert.New(t)
+
+	f := &Field{Masked: true}
+	typeKeys(f, "secret")
+	assert.Equal("******", f.Display())
+	assert.Equal("secret", f.Text())
+	assert.Equal([]rune("secret"), f.Runes())
+
+	//UTF-8 without a string copy
+	typeKeys(f, "ß€")
+	assert.Equal([]byte("secretß€"), f.Bytes())
+	assert.Equal(len("secretß€"), cap(f.Bytes()))
+	typeKeys(f, "\x7f\x7f")
+
+	//removed and erased characters are overwritten
+	typeKeys(f, "\x7f")
+	backing := f.text[:cap(f.text)]
+	assert.Equal(rune(0), backing[5])
+
+	f.Erase()
+	for _, r := range backing {
+		assert.Equal(rune(0), r)
+	}
+	assert.Equal("", f.Display())
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"bufio"
+	"github.com/cruxic/passillion/go/util"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//Oldest sites are forgotten beyond this
+const MaxHistory = 200
+
+/*
+Sitenames used before, most recent first, for suggestions.  Only the
+normalized sitename is kept: never the personalization or the coordinates.
+The file is plaintext, so passn only keeps one when asked (tui -history).
+*/
+type History struct {
+	Sites []string
+}
+
+/*
+$XDG_DATA_HOME/passillion/history, or ~/.local/share/passillion/history when
+XDG_DATA_HOME is not set.
+*/
+func DefaultHistoryPath() (string, error) {
+	dir, err := util.UserDataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "passillion", "history"), nil
+}
+
+/*
+Read the history file: one sitename per line.  A missing file is an empty history.
+*/
+func LoadHistory(path string) (*History, error) {
+	h := &History{}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if site := strings.TrimSpace(scanner.Text()); site != "" && len(h.Sites) < MaxHistory {
+			h.Sites = append(h.Sites, site)
+		}
+	}
+
+	return h, scanner.Err()
+}
+
+/*
+Move site to the front.  site should already be normalized (see type1.NormalizeField).
+*/
+func (self *History) Add(site string) {
+	if site == "" {
+		return
+	}
+
+	sites := []string{site}
+	for _, s := range self.Sites {
+		if s != site && len(sites) < MaxHistory {
+			sites = append(sites, s)
+		}
+	}
+	self.Sites = sites
+}
+
+/*
+Up to max sites which start with prefix (ignoring case), most recent first.
+Sites which contain prefix elsewhere follow.
+*/
+func (self *History) Suggest(prefix string, max int) []string {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+
+	var starts, contains []string
+	for _, site := range self.Sites {
+		lower := strings.ToLower(site)
+		if strings.HasPrefix(lower, prefix) {
+			starts = append(starts, site)
+		} else if strings.Contains(lower, prefix) {
+			contains = append(contains, site)
+		}
+	}
+
+	res := append(starts, contains...)
+	if len(res) > max {
+		res = res[:max]
+	}
+	return res
+}
+
+/*
+Write the history file (mode 0600) creating its directory (0700).
+*/
+func (self *History) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	content := strings.Join(self.Sites, "\n") + "\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		return err
+	}
+
+	return os.Chmod(path, 0600)
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func Test_History(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "history")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sub", "history")
+
+	//missing file
+	h, err := LoadHistory(path)
+	assert.NoError(err)
+	assert.Equal(0, len(h.Sites))
+
+	h.Add("example.com")
+	h.Add("github.com")
+	h.Add("mybank.com")
+	h.Add("")
+	h.Add("example.com")
+	assert.Equal([]string{"example.com", "mybank.com", "github.com"}, h.Sites)
+
+	assert.Equal([]string{"example.com", "mybank.com", "github.com"}, h.Suggest("", 5))
+	assert.Equal([]string{"example.com", "mybank.com"}, h.Suggest("", 2))
+	assert.Equal([]string{"github.com"}, h.Suggest("Git", 5))
+	assert.Equal([]string{"github.com"}, h.Suggest("hub", 5))
+	assert.Equal(0, len(h.Suggest("zzz", 5)))
+
+	assert.NoError(h.Save(path))
+	info, err := os.Stat(path)
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	h2, err := LoadHistory(path)
+	assert.NoError(err)
+	assert.Equal(h.Sites, h2.Sites)
+
+	//prefix matches first, then other matches
+	h.Add("comcast.net")
+	h.Add("zz.com")
+	assert.Equal([]string{"comcast.net", "zz.com", "example.com", "mybank.com"}, h.Suggest("co", 4))
+
+	//limited size
+	for i := 0; i < MaxHistory + 10; i++ {
+		h.Add(fmt.Sprintf("site%d", i))
+	}
+	assert.Equal(MaxHistory, len(h.Sites))
+	assert.Equal(fmt.Sprintf("site%d", MaxHistory + 9), h.Sites[0])
+}
@@ -0,0 +1,137 @@
+/*
+Minimal building blocks for passn's full-screen terminal UI: key decoding,
+editable text fields, a progress bar and the sitename history.  The terminal
+itself is put in raw mode with golang.org/x/crypto/ssh/terminal and drawn
+with ANSI escape sequences.
+*/
+package tui
+
+import (
+	"unicode/utf8"
+)
+
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota  //a printable character, see Key.Rune
+	KeyEnter
+	KeyTab
+	KeyBacktab  //shift+tab
+	KeyBackspace
+	KeyDelete
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyEsc
+	KeyCtrlC
+	KeyCtrlU  //clear the field
+	KeyUnknown
+)
+
+type Key struct {
+	Code KeyCode
+
+	//Only for KeyRune
+	Rune rune
+}
+
+//CSI sequences, ie after "\x1b["
+var gCSIKeys = map[string]KeyCode{
+	"A": KeyUp,
+	"B": KeyDown,
+	"C": KeyRight,
+	"D": KeyLeft,
+	"H": KeyHome,
+	"F": KeyEnd,
+	"Z": KeyBacktab,
+	"1~": KeyHome,
+	"7~": KeyHome,
+	"3~": KeyDelete,
+	"4~": KeyEnd,
+	"8~": KeyEnd,
+}
+
+/*
+Decode the bytes of one read from a raw mode terminal.  An escape sequence
+is assumed to arrive within a single read so a lone "\x1b" is the Esc key.
+*/
+func ParseKeys(buf []byte) []Key {
+	var keys []Key
+
+	for len(buf) > 0 {
+		b := buf[0]
+
+		switch {
+		case b == 0x1b:
+			n, key := parseEscape(buf)
+			keys = append(keys, key)
+			buf = buf[n:]
+			continue
+		case b == '\r' || b == '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case b == '\t':
+			keys = append(keys, Key{Code: KeyTab})
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		case b == 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		case b == 0x15:
+			keys = append(keys, Key{Code: KeyCtrlU})
+		case b == 0x01:  //ctrl+a
+			keys = append(keys, Key{Code: KeyHome})
+		case b == 0x05:  //ctrl+e
+			keys = append(keys, Key{Code: KeyEnd})
+		case b < 0x20:
+			keys = append(keys, Key{Code: KeyUnknown})
+		default:
+			r, size := utf8.DecodeRune(buf)
+			if r == utf8.RuneError {
+				keys = append(keys, Key{Code: KeyUnknown})
+			} else {
+				keys = append(keys, Key{Code: KeyRune, Rune: r})
+			}
+			buf = buf[size:]
+			continue
+		}
+
+		buf = buf[1:]
+	}
+
+	return keys
+}
+
+//Decode an escape sequence at the start of buf.  Returns the number of bytes used.
+func parseEscape(buf []byte) (int, Key) {
+	if len(buf) == 1 {
+		return 1, Key{Code: KeyEsc}
+	}
+
+	//SS3: "\x1bOH" etc (some terminals in application mode)
+	if buf[1] == 'O' && len(buf) >= 3 {
+		if code, found := gCSIKeys[string(buf[2])]; found {
+			return 3, Key{Code: code}
+		}
+		return 3, Key{Code: KeyUnknown}
+	}
+
+	if buf[1] != '[' {
+		//alt+key or esc followed by typing
+		return 1, Key{Code: KeyEsc}
+	}
+
+	//CSI: parameters then one final byte in 0x40-0x7e
+	for i := 2; i < len(buf); i++ {
+		if buf[i] >= 0x40 && buf[i] <= 0x7e {
+			if code, found := gCSIKeys[string(buf[2:i+1])]; found {
+				return i + 1, Key{Code: code}
+			}
+			return i + 1, Key{Code: KeyUnknown}
+		}
+	}
+
+	//incomplete
+	return len(buf), Key{Code: KeyUnknown}
+}
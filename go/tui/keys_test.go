@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	keys := ParseKeys([]byte("aé\r\t\x7f\x03\x15"))
+	assert.Equal([]Key{
+		{Code: KeyRune, Rune: 'a'},
+		{Code: KeyRune, Rune: 'é'},
+		{Code: KeyEnter},
+		{Code: KeyTab},
+		{Code: KeyBackspace},
+		{Code: KeyCtrlC},
+		{Code: KeyCtrlU},
+	}, keys)
+
+	keys = ParseKeys([]byte("\x1b[A\x1b[B\x1b[C\x1b[D\x1b[Z\x1b[3~\x1b[1~\x1b[4~\x1bOH\x1bOF"))
+	assert.Equal([]Key{
+		{Code: KeyUp}, {Code: KeyDown}, {Code: KeyRight}, {Code: KeyLeft}, {Code: KeyBacktab},
+		{Code: KeyDelete}, {Code: KeyHome}, {Code: KeyEnd}, {Code: KeyHome}, {Code: KeyEnd},
+	}, keys)
+
+	//lone escape
+	assert.Equal([]Key{{Code: KeyEsc}}, ParseKeys([]byte("\x1b")))
+
+	//escape then a letter
+	assert.Equal([]Key{{Code: KeyEsc}, {Code: KeyRune, Rune: 'x'}}, ParseKeys([]byte("\x1bx")))
+
+	//unknown sequences are consumed whole
+	assert.Equal([]Key{{Code: KeyUnknown}, {Code: KeyRune, Rune: 'q'}}, ParseKeys([]byte("\x1b[15;2~q")))
+	assert.Equal([]Key{{Code: KeyUnknown}}, ParseKeys([]byte("\x1b[1")))
+
+	//invalid utf8
+	assert.Equal([]Key{{Code: KeyUnknown}, {Code: KeyRune, Rune: 'z'}}, ParseKeys([]byte("\xffz")))
+
+	assert.Equal(0, len(ParseKeys(nil)))
+}
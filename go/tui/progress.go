@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+Progress of a task which gives no progress reports (eg mbcrypt) estimated
+from the elapsed time.  It stays below 1 until the task is done.
+*/
+func EstimatedFraction(elapsed, expected time.Duration) float64 {
+	if expected <= 0 {
+		return 0
+	}
+
+	f := float64(elapsed) / float64(expected)
+	if f > 0.99 {
+		f = 0.99
+	} else if f < 0 {
+		f = 0
+	}
+	return f
+}
+
+/*
+A bar like "[#######-------]  50%" which is width characters wide.
+*/
+func ProgressBar(fraction float64, width int) string {
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+
+	pct := fmt.Sprintf(" %3d%%", int(fraction * 100))
+	inner := width - 2 - len(pct)
+	if inner < 1 {
+		return strings.TrimSpace(pct)
+	}
+
+	filled := int(fraction * float64(inner) + 0.5)
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", inner - filled) + "]" + pct
+}
@@ -0,0 +1,24 @@
+package tui
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"time"
+)
+
+func Test_Progress(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0.5, EstimatedFraction(time.Second, 2 * time.Second))
+	assert.Equal(0.99, EstimatedFraction(3 * time.Second, 2 * time.Second))
+	assert.Equal(0.0, EstimatedFraction(time.Second, 0))
+
+	assert.Equal("[----------]   0%", ProgressBar(0, 17))
+	assert.Equal("[#####-----]  50%", ProgressBar(0.5, 17))
+	assert.Equal("[##########] 100%", ProgressBar(1, 17))
+	assert.Equal("[##########] 100%", ProgressBar(7, 17))
+	assert.Equal(40, len(ProgressBar(0.33, 40)))
+
+	//too narrow for a bar
+	assert.Equal("50%", ProgressBar(0.5, 4))
+}
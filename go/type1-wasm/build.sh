@@ -0,0 +1,22 @@
+#!/bin/bash
+
+set -e  #halt on error
+
+#Compile the type1 package to WebAssembly for website/type1/calc.html
+# (open calc.html?backend=wasm to use it).
+
+cd "$(dirname "$0")"
+out=../../website/type1
+
+GOOS=js GOARCH=wasm go build -o $out/passillion_type1.wasm .
+
+#The JavaScript support file must match the Go version
+goroot=`go env GOROOT`
+if [ -f $goroot/lib/wasm/wasm_exec.js ]
+then
+	cp $goroot/lib/wasm/wasm_exec.js $out/
+else
+	cp $goroot/misc/wasm/wasm_exec.js $out/
+fi
+
+echo "Created $out/passillion_type1.wasm and $out/wasm_exec.js"
@@ -0,0 +1,165 @@
+//go:build js && wasm
+// +build js,wasm
+
+/*
+WebAssembly build of the type1 package so that the website can use the Go
+implementation instead of its TypeScript port.  Build it with build.sh.
+
+It sets the global object passillionType1 with the same functions as
+typescript/passillion_type1.ts:
+
+	calcCheckword(password) string
+	isCorrectCheckword(password, checkword) bool
+	splitCheckword(passwordWithCheckword) [password, checkword]
+	normalizeField(s) string
+	calcSiteHash(password, sitename, personalization) Promise<Uint8Array>
+	getWordCoordinates(hash Uint8Array, nWords) Array<string>
+	MinCoordPassLen
+
+A Go panic inside a callback would kill the wasm instance, so invalid
+arguments return a JavaScript Error object instead of throwing one;
+typescript/type1_wasm.ts throws it.  calcSiteHash rejects its Promise.
+
+Hashing runs in a goroutine so it does not block the page.  Note that wasm
+has one thread so the 4 mbcrypt threads run one after another.
+*/
+package main
+
+import (
+	"errors"
+	"github.com/cruxic/passillion/go/type1"
+	"syscall/js"
+)
+
+func jsError(err error) js.Value {
+	return js.Global().Get("Error").New(err.Error())
+}
+
+//Wrap fn so that its error is returned as a JavaScript Error.
+func jsFunc(fn func(args []js.Value) (interface{}, error)) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		res, err := fn(args)
+		if err != nil {
+			return jsError(err)
+		}
+		return res
+	})
+}
+
+func stringArgs(args []js.Value, n int) ([]string, error) {
+	if len(args) != n {
+		return nil, errors.New("wrong number of arguments")
+	}
+
+	strs := make([]string, n)
+	for i, arg := range args {
+		if arg.Type() != js.TypeString {
+			return nil, errors.New("illegal argument")
+		}
+		strs[i] = arg.String()
+	}
+	return strs, nil
+}
+
+func calcCheckword(args []js.Value) (interface{}, error) {
+	s, err := stringArgs(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return type1.CalcCheckword(s[0]), nil
+}
+
+func isCorrectCheckword(args []js.Value) (interface{}, error) {
+	s, err := stringArgs(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	return type1.IsCorrectCheckword(s[0], s[1]), nil
+}
+
+func splitCheckword(args []js.Value) (interface{}, error) {
+	s, err := stringArgs(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	pass, checkword := type1.SplitCheckword(s[0])
+	return []interface{}{pass, checkword}, nil
+}
+
+func normalizeField(args []js.Value) (interface{}, error) {
+	s, err := stringArgs(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return type1.NormalizeField(s[0]), nil
+}
+
+//Returns a Promise which resolves to the hash as a Uint8Array.
+func calcSiteHash(args []js.Value) (interface{}, error) {
+	s, err := stringArgs(args, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := js.FuncOf(func(this js.Value, resolveReject []js.Value) interface{} {
+		resolve := resolveReject[0]
+		reject := resolveReject[1]
+
+		go func() {
+			hash, err := type1.CalcSiteHash(s[0], s[1], s[2])
+			if err != nil {
+				reject.Invoke(jsError(err))
+				return
+			}
+
+			array := js.Global().Get("Uint8Array").New(len(hash))
+			js.CopyBytesToJS(array, hash)
+			resolve.Invoke(array)
+		}()
+
+		return nil
+	})
+	defer executor.Release()
+
+	return js.Global().Get("Promise").New(executor), nil
+}
+
+func getWordCoordinates(args []js.Value) (interface{}, error) {
+	if len(args) != 2 || !args[0].InstanceOf(js.Global().Get("Uint8Array")) || args[1].Type() != js.TypeNumber {
+		return nil, errors.New("illegal argument")
+	}
+
+	hash := make([]byte, args[0].Length())
+	js.CopyBytesToGo(hash, args[0])
+
+	coords, err := type1.GetWordCoordinates(type1.SiteHash(hash), args[1].Int(), type1.StandardLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]interface{}, len(coords))
+	for i, coord := range coords {
+		res[i] = coord
+	}
+	return res, nil
+}
+
+func main() {
+	obj := js.Global().Get("Object").New()
+	obj.Set("calcCheckword", jsFunc(calcCheckword))
+	obj.Set("isCorrectCheckword", jsFunc(isCorrectCheckword))
+	obj.Set("splitCheckword", jsFunc(splitCheckword))
+	obj.Set("normalizeField", jsFunc(normalizeField))
+	obj.Set("calcSiteHash", jsFunc(calcSiteHash))
+	obj.Set("getWordCoordinates", jsFunc(getWordCoordinates))
+	obj.Set("MinCoordPassLen", type1.MinCoordPassLen)
+	js.Global().Set("passillionType1", obj)
+
+	//tell the page we are ready
+	if onReady := js.Global().Get("onPassillionType1Ready"); onReady.Type() == js.TypeFunction {
+		onReady.Invoke()
+	}
+
+	//keep the functions alive
+	select {}
+}
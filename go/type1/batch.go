@@ -0,0 +1,64 @@
+package type1
+
+import (
+	"runtime"
+	"sync"
+)
+
+/*
+One site for CalcSiteHashes().
+*/
+type SiteInput struct {
+	Sitename string
+	Personalization string
+}
+
+/*
+The outcome for one SiteInput.  Hash is only valid when Err is nil.
+*/
+type SiteResult struct {
+	Hash SiteHash
+	Err error
+}
+
+/*
+Calculate the SiteHash of many sites with the same password.  Each site costs
+the same as CalcSiteHashWithOptions() so the work is spread over a pool of
+at most GOMAXPROCS workers.
+
+The results are in the same order as sites.  A bad site (eg an empty
+sitename) does not stop the others; its error is in the SiteResult.
+The caller still owns password and should erase it afterwards.
+*/
+func CalcSiteHashes(password []byte, sites []SiteInput, opt Options) []SiteResult {
+	results := make([]SiteResult, len(sites))
+
+	nWorkers := runtime.GOMAXPROCS(0)
+	if nWorkers > len(sites) {
+		nWorkers = len(sites)
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < nWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				//each worker writes only its own slots
+				site := sites[i]
+				results[i].Hash, results[i].Err = calcSiteHash(password, site.Sitename, site.Personalization, opt)
+			}
+		}()
+	}
+
+	for i := range sites {
+		indices <- i
+	}
+	close(indices)
+
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,50 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"encoding/hex"
+)
+
+func Test_CalcSiteHashes(t *testing.T) {
+	assert := assert.New(t)
+
+	sites := []SiteInput{
+		{"example.com", "a"},
+		{"", "a"},
+		{"examplf.com", "a"},
+		{"example.com", "b"},
+	}
+
+	pass := []byte("Super Secret")
+	results := CalcSiteHashes(pass, sites, Options{})
+	assert.Equal(4, len(results))
+
+	//same hashes as Test_CalcSiteHash, in order
+	assert.NoError(results[0].Err)
+	assert.Equal("0d7d37b83abbf8e0ff1cd2e2e943c25207f13040167ce68a672e7eb1c9ca15a3", hex.EncodeToString([]byte(results[0].Hash)))
+	assert.Error(results[1].Err)
+	assert.NoError(results[2].Err)
+	assert.Equal("acd8aa32fcd0fd7d4d924d2687d5cbf38ca9ae7174d6dddeb2cb2a79a1c6ac13", hex.EncodeToString([]byte(results[2].Hash)))
+	assert.NoError(results[3].Err)
+	assert.Equal("b8e3f9874f9237d7913149929b529158e04686b1cd43d3c5aee5598081635eb8", hex.EncodeToString([]byte(results[3].Hash)))
+
+	//password is not modified
+	assert.Equal("Super Secret", string(pass))
+
+	//keyfile digest is applied to every site
+	opt := Options{KeyfileDigest: make([]byte, KeyfileLen)}
+	results = CalcSiteHashes(pass, sites[0:1], opt)
+	single, err := CalcSiteHashWithOptions("Super Secret", "example.com", "a", opt)
+	assert.NoError(err)
+	assert.NoError(results[0].Err)
+	assert.Equal(single, results[0].Hash)
+
+	//short password fails every site
+	results = CalcSiteHashes([]byte("short"), sites, Options{})
+	for _, res := range results {
+		assert.Error(res.Err)
+	}
+
+	assert.Equal(0, len(CalcSiteHashes(pass, nil, Options{})))
+}
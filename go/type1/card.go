@@ -0,0 +1,268 @@
+package type1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/util"
+	"github.com/cruxic/passillion/go/wordlist"
+	"strings"
+)
+
+//Length of a card recovery seed (128 bits)
+const CardSeedLen = 16
+
+/*
+One word on the card.
+*/
+type WordCell struct {
+	//The word.  Empty if not assigned.
+	Word string
+
+	//Word number within the quadrant.  0 for the empty cell
+	// at the bottom of the last column of a quadrant.
+	NumInQuad int
+}
+
+/*
+The words of a card arranged according to a Layout.
+With StandardLayout this matches WordLayout in the TypeScript code.
+*/
+type WordLayout struct {
+	Layout *Layout
+
+	//One slice per column, see Layout.ColSizes
+	Columns [][]WordCell
+}
+
+func NewWordLayout(layout *Layout) *WordLayout {
+	res := &WordLayout{
+		Layout: layout,
+		Columns: make([][]WordCell, len(layout.ColSizes)),
+	}
+
+	numInQuad := 1
+	for c := range res.Columns {
+		//reset numInQuad when starting new quadrant
+		if c % layout.ColsPerQuad == 0 {
+			numInQuad = 1
+		}
+
+		res.Columns[c] = make([]WordCell, layout.ColSizes[c])
+		for r := range res.Columns[c] {
+			res.Columns[c][r].NumInQuad = numInQuad
+			numInQuad++
+		}
+	}
+
+	return res
+}
+
+/*
+Fill the columns with words, first column first.
+*/
+func (self *WordLayout) AssignWords(words []string) error {
+	if len(words) != self.Layout.NumWords() {
+		return fmt.Errorf("expected %d words", self.Layout.NumWords())
+	}
+
+	w := 0
+	for c := range self.Columns {
+		for r := range self.Columns[c] {
+			self.Columns[c][r].Word = words[w]
+			w++
+		}
+	}
+
+	return nil
+}
+
+/*
+For a given quadrant (0=top-left, 1=top-right, 2=bottom-left, 3=bottom-right)
+return the rows of cells, one per column.  Columns shorter than the first
+column of the quadrant are padded with empty cells.
+*/
+func (self *WordLayout) GetQuadrantRows(quad int) [][]WordCell {
+	n := self.Layout.ColsPerQuad
+	c := quad * n
+	rows := make([][]WordCell, len(self.Columns[c]))
+
+	for r := range rows {
+		rows[r] = make([]WordCell, n)
+		for i := 0; i < n; i++ {
+			if r < len(self.Columns[c+i]) {
+				rows[r][i] = self.Columns[c+i][r]
+			}
+		}
+	}
+
+	return rows
+}
+
+/*
+Find the word at a coordinate such as "C13".  Returns "" if there is none.
+*/
+func (self *WordLayout) WordAt(coord string) string {
+	index, err := self.Layout.WordIndex(coord)
+	if err != nil {
+		return ""
+	}
+
+	for _, col := range self.Columns {
+		if index < len(col) {
+			return col[index].Word
+		}
+		index -= len(col)
+	}
+
+	return ""
+}
+
+/*
+A short code which identifies the card, eg "fog-ram".  It is two checkwords
+(see CalcCheckword) taken from the SHA-256 of the words in column order so
+it changes if any word moves.  Print it on the card and compare it with the
+card ID passn displays beside the coordinates.
+*/
+func (self *WordLayout) Fingerprint() string {
+	return CardFingerprint(self.Words())
+}
+
+//The words in column order (eg A1, A2, ... Z64).
+func (self *WordLayout) Words() []string {
+	var words []string
+	for _, col := range self.Columns {
+		for _, cell := range col {
+			words = append(words, cell.Word)
+		}
+	}
+	return words
+}
+
+/*
+Fingerprint of card words listed in column order.  See WordLayout.Fingerprint().
+*/
+func CardFingerprint(words []string) string {
+	sha := sha256.New()
+	sha.Write([]byte("passillion-card\n"))
+	for _, word := range words {
+		sha.Write([]byte(word + "\n"))
+	}
+	h := sha.Sum(nil)
+
+	return gCheckwords[h[0]] + "-" + gCheckwords[h[1]]
+}
+
+/*
+Generate a new random recovery seed.
+*/
+func NewCardSeed() ([]byte, error) {
+	rng := util.NewCryptoRandByteSource()
+	defer rng.Erase()
+
+	seed := make([]byte, CardSeedLen)
+	for i := range seed {
+		b, err := rng.NextByte()
+		if err != nil {
+			return nil, err
+		}
+		seed[i] = b
+	}
+
+	return seed, nil
+}
+
+/*
+Format a seed for writing on paper: hex digits in groups of 4.
+*/
+func FormatCardSeed(seed []byte) string {
+	h := hex.EncodeToString(seed)
+
+	var groups []string
+	for len(h) > 4 {
+		groups = append(groups, h[0:4])
+		h = h[4:]
+	}
+	groups = append(groups, h)
+
+	return strings.Join(groups, " ")
+}
+
+/*
+Parse a seed written by FormatCardSeed().  White space and dashes are ignored
+and upper case is accepted.
+*/
+func ParseCardSeed(s string) ([]byte, error) {
+	s = strings.Join(strings.Fields(s), "")
+	s = strings.Replace(s, "-", "", -1)
+	s = strings.ToLower(s)
+
+	seed, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("seed must be hexadecimal")
+	}
+
+	if len(seed) != CardSeedLen {
+		return nil, fmt.Errorf("seed must be %d hex digits", CardSeedLen * 2)
+	}
+
+	return seed, nil
+}
+
+/*
+The words printed on a card with the given layout (unshuffled).  The
+standard card uses the 256 words of words34.ts.  Larger cards use the
+first words of the builtin standard word list.
+*/
+func cardWordsFor(layout *Layout) ([]string, error) {
+	n := layout.NumWords()
+	if n == len(gCardWords) {
+		words := make([]string, n)
+		copy(words, gCardWords[:])
+		return words, nil
+	}
+
+	all, err := wordlist.Get(wordlist.Standard)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(all) < n {
+		return nil, fmt.Errorf("not enough words for a %d word card", n)
+	}
+
+	return all[0:n], nil
+}
+
+/*
+Create the card for a recovery seed.  The seed is the key of an
+HmacCounterByteSource which drives the same shuffle that create.ts
+uses, so the same seed always yields the same card.
+*/
+func CardFromSeed(seed []byte, layout *Layout) (*WordLayout, error) {
+	if len(seed) != CardSeedLen {
+		return nil, fmt.Errorf("seed must be %d bytes", CardSeedLen)
+	}
+
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
+
+	words, err := cardWordsFor(layout)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := util.NewHmacCounterByteSource(seed, 0xFFFFFFFF)
+	if err := util.SecureShuffleStrings(words, rng); err != nil {
+		return nil, err
+	}
+
+	card := NewWordLayout(layout)
+	if err := card.AssignWords(words); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
@@ -0,0 +1,172 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/cruxic/passillion/go/wordlist"
+	"sort"
+	"strings"
+)
+
+func Test_cardWords(t *testing.T) {
+	assert := assert.New(t)
+
+	m := make(map[string]bool)
+	for _, word := range gCardWords {
+		assert.False(m[word])
+		m[word] = true
+	}
+	assert.Equal(256, len(m))
+}
+
+func Test_cardWordsMatchWebsite(t *testing.T) {
+	assert := assert.New(t)
+
+	//gCardWords is a copy of the list the web calculator uses
+	words, err := wordlist.Get("../../website/type1/words34.ts")
+	assert.NoError(err)
+	assert.Equal(words, gCardWords[:])
+
+	//and neither may change: every card printed would be wrong
+	h := sha256.Sum256([]byte(strings.Join(gCardWords[:], "\n")))
+	assert.Equal("484b48e6145d0680b4bab1cf64665c4748c37aeb525faf5e5296ded9171ab5bb", hex.EncodeToString(h[:]))
+}
+
+func Test_WordLayout(t *testing.T) {
+	assert := assert.New(t)
+
+	words := make([]string, 256)
+	for i := range words {
+		words[i] = string(rune('a' + i % 26)) + string(rune('a' + i / 26))
+	}
+
+	layout := NewWordLayout(StandardLayout)
+	assert.Error(layout.AssignWords(words[1:]))
+	assert.NoError(layout.AssignWords(words))
+
+	//same placement as GetWordCoordinates
+	for i, word := range words {
+		coord := StandardLayout.Coordinate(i)
+		assert.Equal(word, layout.WordAt(coord), coord)
+	}
+
+	assert.Equal("", layout.WordAt("A0"))
+	assert.Equal("", layout.WordAt("A61"))
+	assert.Equal("", layout.WordAt("G1"))
+	assert.Equal("", layout.WordAt("A"))
+
+	rows := layout.GetQuadrantRows(0)
+	assert.Equal(20, len(rows))
+	assert.Equal(WordCell{words[0], 1}, rows[0][0])
+	assert.Equal(WordCell{words[20], 21}, rows[0][1])
+	assert.Equal(WordCell{words[40], 41}, rows[0][2])
+
+	//last column of the last quadrant is shorter
+	rows = layout.GetQuadrantRows(3)
+	assert.Equal(22, len(rows))
+	assert.Equal(WordCell{words[255], 64}, rows[19][2])
+	assert.Equal(WordCell{}, rows[20][2])
+	assert.Equal(3, len(rows[21]))
+	assert.Equal(WordCell{}, rows[21][2])
+}
+
+func Test_CardSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	assert.Equal("0001 0203 0405 0607 0809 0a0b 0c0d 0e0f", FormatCardSeed(seed))
+
+	seed2, err := ParseCardSeed(" 0001-0203 0405 0607 0809 0A0B 0C0D 0E0F\n")
+	assert.NoError(err)
+	assert.Equal(seed, seed2)
+
+	_, err = ParseCardSeed("0001 0203")
+	assert.Error(err)
+	_, err = ParseCardSeed("000102030405060708090a0b0c0d0e0g")
+	assert.Error(err)
+
+	seed, err = NewCardSeed()
+	assert.NoError(err)
+	assert.Equal(CardSeedLen, len(seed))
+	seed2, err = NewCardSeed()
+	assert.NoError(err)
+	assert.NotEqual(seed, seed2)
+}
+
+func Test_CardFromSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := CardFromSeed([]byte("short"), StandardLayout)
+	assert.Error(err)
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	card, err := CardFromSeed(seed, StandardLayout)
+	assert.NoError(err)
+
+	//reproducible
+	card2, err := CardFromSeed(seed, StandardLayout)
+	assert.NoError(err)
+	assert.Equal(card, card2)
+
+	//every card word exactly once
+	words := card.Words()
+	sorted := append([]string{}, words...)
+	sort.Strings(sorted)
+	expect := append([]string{}, gCardWords[:]...)
+	sort.Strings(expect)
+	assert.Equal(expect, sorted)
+
+	//Known answer so other implementations can verify
+	assert.Equal("cite avow lacy", card.WordAt("A1") + " " + card.WordAt("C60") + " " + card.WordAt("Z64"))
+
+	//different seed, different card
+	seed[15] ^= 1
+	card2, err = CardFromSeed(seed, StandardLayout)
+	assert.NoError(err)
+	assert.NotEqual(words, card2.Words())
+}
+
+func Test_CardFingerprint(t *testing.T) {
+	assert := assert.New(t)
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	card, _ := CardFromSeed(seed, StandardLayout)
+	words := card.Words()
+	fp := card.Fingerprint()
+	assert.Equal("hub-any", fp)
+	assert.Equal(fp, CardFingerprint(words))
+
+	//swap two words
+	words[0], words[1] = words[1], words[0]
+	assert.NotEqual(fp, CardFingerprint(words))
+
+	assert.Equal("tip-lab", CardFingerprint(gCardWords[:]))
+}
+
+func Test_CardFromSeedLarger(t *testing.T) {
+	assert := assert.New(t)
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	for _, layout := range []*Layout{Layout512, Layout1024} {
+		card, err := CardFromSeed(seed, layout)
+		assert.NoError(err)
+
+		words := card.Words()
+		assert.Equal(layout.NumWords(), len(words))
+		m := make(map[string]bool)
+		for _, word := range words {
+			m[word] = true
+		}
+		assert.Equal(layout.NumWords(), len(m))
+
+		//every coordinate finds its word
+		for i, word := range words {
+			assert.Equal(word, card.WordAt(layout.Coordinate(i)))
+		}
+
+		rows := card.GetQuadrantRows(layout.NumQuads() - 1)
+		assert.Equal(layout.ColsPerQuad, len(rows[0]))
+	}
+}
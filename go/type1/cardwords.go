@@ -0,0 +1,262 @@
+package type1
+
+//The 256 words printed on a card (same order as website/type1/words34.ts).
+// CardFromSeed() shuffles a copy of this list.
+var gCardWords = [256]string {
+	"zulu",
+	"jam",
+	"run",
+	"whim",
+	"arab",
+	"ajar",
+	"mite",
+	"chew",
+	"wart",
+	"cite",
+	"turk",
+	"sled",
+	"dust",
+	"came",
+	"loyd",
+	"edge",
+	"brit",
+	"arch",
+	"dim",
+	"temp",
+	"tong",
+	"tron",
+	"rude",
+	"call",
+	"unto",
+	"acid",
+	"rub",
+	"peer",
+	"fuse",
+	"rib",
+	"avow",
+	"holy",
+	"wake",
+	"van",
+	"fist",
+	"pomp",
+	"hush",
+	"goon",
+	"oahu",
+	"lord",
+	"dada",
+	"foxy",
+	"heal",
+	"sip",
+	"suss",
+	"put",
+	"know",
+	"comb",
+	"ryan",
+	"gape",
+	"biff",
+	"tug",
+	"else",
+	"lore",
+	"nero",
+	"wimp",
+	"para",
+	"hut",
+	"got",
+	"arid",
+	"glad",
+	"urge",
+	"davy",
+	"boss",
+	"pose",
+	"dean",
+	"each",
+	"ipod",
+	"dive",
+	"zip",
+	"case",
+	"brad",
+	"mire",
+	"fox",
+	"cowl",
+	"geek",
+	"dear",
+	"oak",
+	"wise",
+	"sad",
+	"web",
+	"corn",
+	"yore",
+	"hong",
+	"owen",
+	"crow",
+	"yank",
+	"tune",
+	"care",
+	"rote",
+	"pied",
+	"troy",
+	"pure",
+	"russ",
+	"tate",
+	"flip",
+	"lost",
+	"burt",
+	"berg",
+	"heat",
+	"mace",
+	"died",
+	"kind",
+	"fan",
+	"lamp",
+	"hill",
+	"guff",
+	"they",
+	"buzz",
+	"peg",
+	"silo",
+	"flow",
+	"tort",
+	"gasp",
+	"bail",
+	"nerd",
+	"toot",
+	"inky",
+	"dark",
+	"eddy",
+	"stet",
+	"anti",
+	"wold",
+	"mom",
+	"girl",
+	"have",
+	"okay",
+	"nark",
+	"jerk",
+	"darn",
+	"doug",
+	"wing",
+	"romp",
+	"size",
+	"lego",
+	"brag",
+	"achy",
+	"iron",
+	"loco",
+	"poky",
+	"meme",
+	"vape",
+	"nap",
+	"flag",
+	"list",
+	"zero",
+	"cloy",
+	"dona",
+	"misc",
+	"demo",
+	"farm",
+	"use",
+	"afro",
+	"into",
+	"dave",
+	"burg",
+	"wove",
+	"saga",
+	"body",
+	"slay",
+	"sale",
+	"mini",
+	"good",
+	"mope",
+	"pet",
+	"rink",
+	"keep",
+	"hang",
+	"blog",
+	"vote",
+	"fork",
+	"roam",
+	"cram",
+	"host",
+	"jill",
+	"fave",
+	"vast",
+	"vary",
+	"slip",
+	"fare",
+	"paw",
+	"chit",
+	"geld",
+	"year",
+	"hear",
+	"wolf",
+	"task",
+	"cell",
+	"pain",
+	"flax",
+	"bond",
+	"cord",
+	"erin",
+	"sure",
+	"lace",
+	"lacy",
+	"rasp",
+	"flux",
+	"glum",
+	"sly",
+	"crab",
+	"smog",
+	"tint",
+	"luck",
+	"mike",
+	"tan",
+	"gum",
+	"tip",
+	"muff",
+	"elf",
+	"peck",
+	"shot",
+	"hoof",
+	"jodi",
+	"rung",
+	"vain",
+	"noon",
+	"sect",
+	"cuff",
+	"seed",
+	"zeus",
+	"ever",
+	"zeal",
+	"sewn",
+	"calf",
+	"fur",
+	"any",
+	"itch",
+	"beta",
+	"drag",
+	"wife",
+	"took",
+	"soup",
+	"wax",
+	"walk",
+	"deck",
+	"cray",
+	"oboe",
+	"slag",
+	"hurt",
+	"next",
+	"norm",
+	"burn",
+	"hoot",
+	"wist",
+	"veto",
+	"bay",
+	"glow",
+	"shed",
+	"felt",
+	"bar",
+	"lang",
+	"brig",
+	"silt",
+	"cert",
+	"knew",
+}
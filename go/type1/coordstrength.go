@@ -0,0 +1,37 @@
+package type1
+
+import (
+	"github.com/cruxic/passillion/go/strength"
+)
+
+/*
+Recommended minimum entropy (bits) of a coordinate password.  Each guess
+costs an attacker the equivalent of bcrypt cost 13, yet the whole system's
+security rests on this one password.
+*/
+const MinCoordPassBits = 40
+
+/*
+Estimate the entropy of a coordinate password.  The checkword must already
+be removed (see SplitCheckword) since it adds no entropy.
+*/
+func EstimateCoordPassStrength(password string) strength.Result {
+	return strength.Estimate(password)
+}
+
+//EstimateCoordPassStrength for a password held in a rune slice.
+func EstimateCoordPassStrengthRunes(password []rune) strength.Result {
+	return strength.EstimateRunes(password)
+}
+
+/*
+Return true if the coordinate password has at least minBits of estimated entropy.
+*/
+func IsStrongCoordPass(password string, minBits float64) bool {
+	if len(password) < MinCoordPassLen {
+		return false
+	}
+
+	res := EstimateCoordPassStrength(password)
+	return res.Bits >= minBits
+}
@@ -0,0 +1,20 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsStrongCoordPass(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(IsStrongCoordPass("aaaaaaaaaa", MinCoordPassBits))
+	assert.False(IsStrongCoordPass("Password1234", MinCoordPassBits))
+	assert.False(IsStrongCoordPass("qwertyuiop", MinCoordPassBits))
+	assert.False(IsStrongCoordPass("short", 0))
+
+	assert.True(IsStrongCoordPass("Drizzle quokka 7 velvet", MinCoordPassBits))
+
+	//threshold is configurable
+	assert.True(IsStrongCoordPass("aaaaaaaaaa", 5))
+}
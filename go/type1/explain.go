@@ -0,0 +1,197 @@
+package type1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+/*
+Attacker hardware and prices used by Explain().  The defaults are for one
+high end consumer GPU (hashcat benchmarks of an RTX 4090) rented by the hour.
+*/
+type AttackAssumptions struct {
+	//bcrypt hashes per second at cost 5 on one GPU
+	BcryptCost5PerSec float64
+
+	//Guesses per second on one GPU when a site stores an unsalted fast
+	// hash such as SHA-256.  The worst case for a leaked database.
+	FastHashPerSec float64
+
+	DollarsPerGPUHour float64
+}
+
+func DefaultAttackAssumptions() AttackAssumptions {
+	return AttackAssumptions{
+		BcryptCost5PerSec: 184000,
+		FastHashPerSec: 2.2e10,
+		DollarsPerGPUHour: 0.50,
+	}
+}
+
+/*
+The expected effort of one attack.
+*/
+type Scenario struct {
+	Name string
+	Description string
+
+	//Entropy the attacker must search
+	Bits float64
+
+	//Guesses per second on one GPU
+	GuessesPerSec float64
+
+	//Expected time (half the search space) on one GPU and its rental price
+	GPUYears float64
+	Dollars float64
+}
+
+/*
+Security report for a derivation.  See Explain().
+*/
+type Explanation struct {
+	Layout *Layout
+	NWords int
+	DistinctWords bool
+
+	//Entropy of the site password, assuming the attacker does not have the card.
+	// The policy (capitalize the first word, end with one digit) adds nothing
+	// since the attacker knows it.
+	SitePasswordBits float64
+
+	//Estimated entropy of the coordinate password
+	CoordPassBits float64
+
+	//mbcrypt guesses per second on one GPU
+	KDFGuessesPerSec float64
+
+	//A keyfile or pepper (see Options) is mixed into every site hash
+	Keyfile bool
+	Pepper bool
+
+	Scenarios []Scenario
+}
+
+func newScenario(name, description string, bits, guessesPerSec float64, assume AttackAssumptions) Scenario {
+	const secondsPerYear = 365.25 * 24 * 3600
+
+	//expected guesses: half the search space
+	seconds := math.Pow(2, bits - 1) / guessesPerSec
+
+	return Scenario{
+		Name: name,
+		Description: description,
+		Bits: bits,
+		GuessesPerSec: guessesPerSec,
+		GPUYears: seconds / secondsPerYear,
+		Dollars: seconds / 3600 * assume.DollarsPerGPUHour,
+	}
+}
+
+/*
+Entropy of nWords coordinates on the layout.  Every coordinate is uniform
+because each consumes a whole number of hash bits.  In DistinctWords mode
+the words are a uniform arrangement without repeats.
+*/
+func sitePasswordBits(nWords int, layout *Layout, distinct bool) float64 {
+	if !distinct {
+		return float64(nWords * layout.BitsPerWord())
+	}
+
+	n := float64(layout.NumWords())
+	bits := 0.0
+	for i := 0; i < nWords; i++ {
+		bits += math.Log2(n - float64(i))
+	}
+	return bits
+}
+
+//"a", "a and b", "a, b and c"
+func joinAnd(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+/*
+Compute the entropy of a site password and of the coordinate password
+(coordPassBits, eg from EstimateCoordPassStrength) and the cost of the two
+attacks discussed in the makeSiteId() comment:
+
+"database leak": a site leaks its password hashes.  Without the card the
+attacker must search every site password (worst case: the site used a fast
+unsalted hash).  Success reveals only that site's password.
+
+"card compromise": the attacker holds the physical card and one plaintext
+site password.  Each guess of the coordinate password costs one mbcrypt
+hash (4 bcrypt threads of cost 11) and success reveals every site.
+With a keyfile or pepper the attacker must also guess those (a keyfile
+made by passn has KeyfileLen random bytes), so a third scenario shows the
+attacker who has stolen them too.
+*/
+func Explain(coordPassBits float64, nWords int, layout *Layout, opt Options, assume AttackAssumptions) (*Explanation, error) {
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
+
+	if nWords < 1 || nWords > 256 / layout.BitsPerWord() {
+		return nil, errors.New("nWords out of range")
+	}
+
+	if assume.BcryptCost5PerSec <= 0 || assume.FastHashPerSec <= 0 {
+		return nil, errors.New("attacker speeds must be positive")
+	}
+
+	res := &Explanation{
+		Layout: layout,
+		NWords: nWords,
+		DistinctWords: opt.DistinctWords,
+		SitePasswordBits: sitePasswordBits(nWords, layout, opt.DistinctWords),
+		CoordPassBits: coordPassBits,
+		Keyfile: len(opt.KeyfileDigest) > 0,
+		Pepper: len(opt.Pepper) > 0,
+	}
+
+	//every cost increment doubles the work; threads run one after the other on an attacker's GPU
+	res.KDFGuessesPerSec = assume.BcryptCost5PerSec / math.Pow(2, KDFCost - 5) / KDFThreads
+
+	kdf := fmt.Sprintf("Every guess costs %d bcrypt hashes of cost %d. Cracking reveals all sites.", KDFThreads, KDFCost)
+
+	//secrets besides the card and coordinate password
+	var secrets []string
+	secretBits := 0
+	if res.Keyfile {
+		secrets = append(secrets, "keyfile")
+		secretBits += KeyfileLen * 8
+	}
+	if res.Pepper {
+		secrets = append(secrets, "pepper")
+		secretBits += len(opt.Pepper) * 8
+	}
+
+	res.Scenarios = []Scenario{
+		newScenario("database leak",
+			"A site leaks unsalted fast hashes. Cracking reveals that site's password only.",
+			res.SitePasswordBits, assume.FastHashPerSec, assume),
+	}
+
+	if len(secrets) == 0 {
+		res.Scenarios = append(res.Scenarios, newScenario("card compromise",
+			"Attacker has the card and one site password. " + kdf,
+			coordPassBits, res.KDFGuessesPerSec, assume))
+	} else {
+		stolen := joinAnd(secrets)
+		res.Scenarios = append(res.Scenarios,
+			newScenario("card compromise",
+				fmt.Sprintf("Attacker has the card and one site password but not the %s, which must be guessed too. %s", stolen, kdf),
+				coordPassBits + float64(secretBits), res.KDFGuessesPerSec, assume),
+			newScenario(joinAnd(append([]string{"card"}, secrets...)) + " compromise",
+				fmt.Sprintf("Attacker also has the %s. %s", stolen, kdf),
+				coordPassBits, res.KDFGuessesPerSec, assume))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,77 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"math"
+)
+
+func Test_sitePasswordBits(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(32.0, sitePasswordBits(4, StandardLayout, false))
+	assert.Equal(40.0, sitePasswordBits(4, Layout1024, false))
+
+	//256 * 255 * 254 * 253
+	assert.InDelta(math.Log2(256 * 255 * 254 * 253), sitePasswordBits(4, StandardLayout, true), 1e-9)
+	assert.Equal(8.0, sitePasswordBits(1, StandardLayout, true))
+}
+
+func Test_Explain(t *testing.T) {
+	assert := assert.New(t)
+
+	assume := AttackAssumptions{
+		BcryptCost5PerSec: 512,
+		FastHashPerSec: 1 << 20,
+		DollarsPerGPUHour: 1,
+	}
+
+	ex, err := Explain(41, 4, StandardLayout, Options{}, assume)
+	assert.NoError(err)
+	assert.Equal(32.0, ex.SitePasswordBits)
+	assert.Equal(41.0, ex.CoordPassBits)
+
+	//512 / 2^6 / 4
+	assert.Equal(2.0, ex.KDFGuessesPerSec)
+
+	assert.Equal(2, len(ex.Scenarios))
+
+	leak := ex.Scenarios[0]
+	assert.Equal("database leak", leak.Name)
+	//2^31 / 2^20 = 2048 seconds
+	assert.InDelta(2048.0 / 3600, leak.Dollars, 1e-9)
+
+	card := ex.Scenarios[1]
+	assert.Equal("card compromise", card.Name)
+	assert.Equal(41.0, card.Bits)
+	//2^40 / 2 seconds
+	assert.InDelta(math.Pow(2, 39) / (365.25 * 24 * 3600), card.GPUYears, 1e-6)
+
+	assert.False(ex.Keyfile)
+	assert.False(ex.Pepper)
+
+	//the keyfile and pepper must be guessed unless stolen too
+	ex, err = Explain(41, 4, StandardLayout, Options{KeyfileDigest: make([]byte, 32), Pepper: make([]byte, 32)}, assume)
+	assert.NoError(err)
+	assert.True(ex.Keyfile)
+	assert.True(ex.Pepper)
+	assert.Equal(3, len(ex.Scenarios))
+	assert.Equal("card compromise", ex.Scenarios[1].Name)
+	assert.Equal(41.0 + 512, ex.Scenarios[1].Bits)
+	assert.Contains(ex.Scenarios[1].Description, "keyfile and pepper")
+	assert.Equal("card, keyfile and pepper compromise", ex.Scenarios[2].Name)
+	assert.Equal(card.Bits, ex.Scenarios[2].Bits)
+	assert.Equal(card.GPUYears, ex.Scenarios[2].GPUYears)
+
+	ex, err = Explain(41, 4, StandardLayout, Options{KeyfileDigest: make([]byte, 32)}, assume)
+	assert.NoError(err)
+	assert.Equal(41.0 + 256, ex.Scenarios[1].Bits)
+	assert.Equal("card and keyfile compromise", ex.Scenarios[2].Name)
+
+	_, err = Explain(41, 33, StandardLayout, Options{}, assume)
+	assert.Error(err)
+	_, err = Explain(41, 26, Layout1024, Options{}, assume)
+	assert.Error(err)
+	_, err = Explain(41, 4, StandardLayout, Options{}, AttackAssumptions{})
+	assert.Error(err)
+}
@@ -0,0 +1,80 @@
+package type1
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/cruxic/passillion/go/util"
+	"io"
+	"os"
+)
+
+//Number of random bytes in a new keyfile
+const KeyfileLen = 32
+
+/*
+Digest of a keyfile's content.  Any file can be a keyfile (like KeePass);
+every byte of it matters.
+*/
+func KeyfileDigest(r io.Reader) ([]byte, error) {
+	hm := hmac.New(sha256.New, []byte("passillion-type1 keyfile"))
+	if _, err := io.Copy(hm, r); err != nil {
+		return nil, err
+	}
+	return hm.Sum(nil), nil
+}
+
+/*
+Read a keyfile and return its digest for Options.KeyfileDigest.
+*/
+func ReadKeyfile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return KeyfileDigest(f)
+}
+
+/*
+Create a new keyfile of random hex text (readable by user only).
+An existing file is never overwritten.
+*/
+func WriteNewKeyfile(path string, rng util.ByteSource) error {
+	key := make([]byte, KeyfileLen)
+	defer util.Erase(key)
+
+	for i := range key {
+		b, err := rng.NextByte()
+		if err != nil {
+			return err
+		}
+		key[i] = b
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY | os.O_CREATE | os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "passillion keyfile\n%s\n", hex.EncodeToString(key))
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+
+	if err != nil {
+		os.Remove(path)
+	}
+
+	return err
+}
+
+/*
+Combine the password with a keyfile digest: HMAC-SHA256 keyed with the
+digest.  Without the keyfile the password alone is useless.
+*/
+func combineKeyfile(password []byte, keyfileDigest []byte) []byte {
+	return util.HmacSha256(keyfileDigest, password)
+}
@@ -0,0 +1,69 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/cruxic/passillion/go/util"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func Test_Keyfile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "keyfile")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "my.key")
+	rng := &util.FixedByteSource{Bytes: util.ByteSequence(0, 64)}
+	assert.NoError(WriteNewKeyfile(path, rng))
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(err)
+	assert.Equal("passillion keyfile\n000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f\n", string(content))
+
+	info, err := os.Stat(path)
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	//never overwrite
+	assert.Error(WriteNewKeyfile(path, rng))
+
+	//not enough randomness
+	assert.Error(WriteNewKeyfile(filepath.Join(dir, "short.key"), &util.FixedByteSource{Bytes: []byte{1}}))
+	_, err = os.Stat(filepath.Join(dir, "short.key"))
+	assert.True(os.IsNotExist(err))
+
+	digest, err := ReadKeyfile(path)
+	assert.NoError(err)
+	digest2, err := KeyfileDigest(strings.NewReader(string(content)))
+	assert.NoError(err)
+	assert.Equal(digest, digest2)
+	assert.Equal("7014535d9ac058403905a016f9014ba9d703dbdb32a4021449765fd3c22f8b28", hex.EncodeToString(digest))
+
+	_, err = ReadKeyfile(filepath.Join(dir, "missing"))
+	assert.Error(err)
+}
+
+func Test_CalcSiteHashKeyfile(t *testing.T) {
+	assert := assert.New(t)
+
+	digest, _ := KeyfileDigest(strings.NewReader("any file will do"))
+
+	siteha, err := CalcSiteHashWithOptions("Super Secret", "example.com", "a", Options{KeyfileDigest: digest})
+	assert.NoError(err)
+	assert.Equal("5e041e2d9e41f02b85b977fe053d3694f3edcdcec8d57105e2004cf3b773d885", hex.EncodeToString([]byte(siteha)))
+
+	//without the keyfile: the original hash
+	siteha, err = CalcSiteHashWithOptions("Super Secret", "example.com", "a", Options{})
+	assert.NoError(err)
+	assert.Equal("0d7d37b83abbf8e0ff1cd2e2e943c25207f13040167ce68a672e7eb1c9ca15a3", hex.EncodeToString([]byte(siteha)))
+
+	//still need a long password
+	_, err = CalcSiteHashWithOptions("short", "example.com", "a", Options{KeyfileDigest: digest})
+	assert.Error(err)
+}
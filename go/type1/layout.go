@@ -0,0 +1,195 @@
+package type1
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Describes the geometry of a card: how many words it has and how they are
+arranged in lettered columns.  Columns are grouped into quadrants and the
+word numbers restart at 1 in each quadrant.
+
+The number of words is a power of two so that each word consumes a whole
+number of bits from the SiteHash.
+*/
+type Layout struct {
+	Name string
+
+	//One header letter per column
+	Letters string
+
+	//Number of words in each column
+	ColSizes []int
+
+	//Number of columns in each quadrant
+	ColsPerQuad int
+}
+
+/*
+The original 256 word card: 12 columns in 4 quadrants of 3.
+The first three columns and the very last column have 20 words, all others 22.
+*/
+var StandardLayout = &Layout{
+	Name: "standard",
+	Letters: ColumnLetters,
+	ColSizes: []int{20, 20, 20, 22, 22, 22, 22, 22, 22, 22, 22, 20},
+	ColsPerQuad: 3,
+}
+
+//512 words: 16 columns of 32 in 4 quadrants of 4.
+var Layout512 = &Layout{
+	Name: "512",
+	Letters: "ABCDEFGHJKLMNPQR",
+	ColSizes: []int{
+		32, 32, 32, 32, 32, 32, 32, 32,
+		32, 32, 32, 32, 32, 32, 32, 32,
+	},
+	ColsPerQuad: 4,
+}
+
+//1024 words: 24 columns in 4 quadrants of 6.  The first 16 columns have 43
+// words and the last 8 have 42.
+var Layout1024 = &Layout{
+	Name: "1024",
+	Letters: "ABCDEFGHJKLMNPQRSTUVWXYZ",
+	ColSizes: []int{
+		43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43,
+		43, 43, 43, 43, 42, 42, 42, 42, 42, 42, 42, 42,
+	},
+	ColsPerQuad: 6,
+}
+
+var gLayouts = []*Layout{StandardLayout, Layout512, Layout1024}
+
+/*
+Find a layout by name: "standard" (or "256"), "512" or "1024".
+*/
+func GetLayout(name string) (*Layout, error) {
+	if name == "" || name == "256" {
+		return StandardLayout, nil
+	}
+
+	for _, layout := range gLayouts {
+		if layout.Name == name {
+			return layout, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown layout \"%s\"", name)
+}
+
+func (self *Layout) NumWords() int {
+	n := 0
+	for _, size := range self.ColSizes {
+		n += size
+	}
+	return n
+}
+
+//Number of SiteHash bits consumed per word.  0 if NumWords() is not a power of two.
+func (self *Layout) BitsPerWord() int {
+	n := self.NumWords()
+	for bits := 1; bits <= 16; bits++ {
+		if n == 1 << uint(bits) {
+			return bits
+		}
+	}
+	return 0
+}
+
+func (self *Layout) validate() error {
+	if len(self.Letters) != len(self.ColSizes) || len(self.ColSizes) == 0 {
+		return errors.New("layout needs one letter per column")
+	}
+
+	if self.ColsPerQuad < 1 || len(self.ColSizes) % self.ColsPerQuad != 0 {
+		return errors.New("layout columns do not divide into quadrants")
+	}
+
+	if self.BitsPerWord() == 0 {
+		return errors.New("layout must have a power of two words")
+	}
+
+	return nil
+}
+
+func (self *Layout) NumQuads() int {
+	return len(self.ColSizes) / self.ColsPerQuad
+}
+
+/*
+Given a word index (0 to NumWords()-1) get the column index and the word
+number within that column.  Note: word numbers are unique within the
+entire quadrant.
+*/
+func (self *Layout) columnAndWordNumber(wordIndex int) (col, wordNumber int) {
+	if wordIndex < 0 || wordIndex >= self.NumWords() {
+		panic("wordIndex out of range")
+	}
+
+	numInQuad := 1
+	for col, size := range self.ColSizes {
+		//reset numInQuad when starting new quadrant
+		if col % self.ColsPerQuad == 0 {
+			numInQuad = 1
+		}
+
+		if wordIndex < size {
+			return col, numInQuad + wordIndex
+		}
+
+		wordIndex -= size
+		numInQuad += size
+	}
+
+	//will never reach here
+	panic("assert fail")
+}
+
+/*
+Coordinate of a word index, eg "C13".
+*/
+func (self *Layout) Coordinate(wordIndex int) string {
+	col, num := self.columnAndWordNumber(wordIndex)
+	return fmt.Sprintf("%c%d", self.Letters[col], num)
+}
+
+/*
+Inverse of Coordinate().  Lower case letters are accepted.
+*/
+func (self *Layout) WordIndex(coord string) (int, error) {
+	coord = strings.ToUpper(strings.TrimSpace(coord))
+	if len(coord) < 2 {
+		return 0, errors.New("coordinate is too short")
+	}
+
+	col := strings.IndexByte(self.Letters, coord[0])
+	if col < 0 {
+		return 0, fmt.Errorf("no column %c", coord[0])
+	}
+
+	num, err := strconv.Atoi(coord[1:])
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinate \"%s\"", coord)
+	}
+
+	//find the first word number of the column and its index
+	quadStart := col - col % self.ColsPerQuad
+	first := 1
+	index := 0
+	for c := 0; c < col; c++ {
+		if c >= quadStart {
+			first += self.ColSizes[c]
+		}
+		index += self.ColSizes[c]
+	}
+
+	if num < first || num >= first + self.ColSizes[col] {
+		return 0, fmt.Errorf("no coordinate %s", coord)
+	}
+
+	return index + num - first, nil
+}
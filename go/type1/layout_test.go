@@ -0,0 +1,107 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"strings"
+)
+
+func Test_Layouts(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, layout := range gLayouts {
+		assert.NoError(layout.validate(), layout.Name)
+
+		found, err := GetLayout(layout.Name)
+		assert.NoError(err)
+		assert.Equal(layout, found)
+	}
+
+	assert.Equal(256, StandardLayout.NumWords())
+	assert.Equal(8, StandardLayout.BitsPerWord())
+	assert.Equal(4, StandardLayout.NumQuads())
+	assert.Equal(512, Layout512.NumWords())
+	assert.Equal(9, Layout512.BitsPerWord())
+	assert.Equal(1024, Layout1024.NumWords())
+	assert.Equal(10, Layout1024.BitsPerWord())
+	assert.Equal(4, Layout1024.NumQuads())
+
+	layout, err := GetLayout("256")
+	assert.NoError(err)
+	assert.Equal(StandardLayout, layout)
+	_, err = GetLayout("128")
+	assert.Error(err)
+
+	bad := &Layout{Letters: "AB", ColSizes: []int{2, 3}, ColsPerQuad: 1}
+	assert.Error(bad.validate())
+	bad = &Layout{Letters: "A", ColSizes: []int{2, 2}, ColsPerQuad: 1}
+	assert.Error(bad.validate())
+	bad = &Layout{Letters: "ABC", ColSizes: []int{2, 1, 1}, ColsPerQuad: 2}
+	assert.Error(bad.validate())
+}
+
+func Test_LayoutCoordinates(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, layout := range gLayouts {
+		seen := make(map[string]bool)
+		for i := 0; i < layout.NumWords(); i++ {
+			coord := layout.Coordinate(i)
+			assert.False(seen[coord])
+			seen[coord] = true
+
+			index, err := layout.WordIndex(coord)
+			assert.NoError(err)
+			assert.Equal(i, index)
+		}
+	}
+
+	assert.Equal("A1", Layout512.Coordinate(0))
+	assert.Equal("B33", Layout512.Coordinate(32))
+	assert.Equal("D128", Layout512.Coordinate(127))
+	assert.Equal("E1", Layout512.Coordinate(128))
+	assert.Equal("R128", Layout512.Coordinate(511))
+
+	assert.Equal("F258", Layout1024.Coordinate(257))
+	assert.Equal("G1", Layout1024.Coordinate(258))
+	assert.Equal("Z252", Layout1024.Coordinate(1023))
+
+	index, err := StandardLayout.WordIndex(" z64")
+	assert.NoError(err)
+	assert.Equal(255, index)
+
+	for _, bad := range []string{"", "A", "A0", "A21", "B20", "G1", "Ax"} {
+		_, err = StandardLayout.WordIndex(bad)
+		assert.Error(err, bad)
+	}
+}
+
+func Test_GetWordCoordinatesLayouts(t *testing.T) {
+	assert := assert.New(t)
+
+	hash := SiteHash(makeSeq(0, 32))
+
+	//standard layout reads whole bytes like before
+	coords, err := GetWordCoordinates(hash, 32, StandardLayout)
+	assert.NoError(err)
+	assert.Equal("A1 A2 A3 A4", strings.Join(coords[0:4], " "))
+
+	//00000000 00000001 00000010 ... read 9 bits at a time
+	coords, err = GetWordCoordinates(hash, 28, Layout512)
+	assert.NoError(err)
+	assert.Equal(28, len(coords))
+	assert.Equal(Layout512.Coordinate(0) + " " + Layout512.Coordinate(4) + " " + Layout512.Coordinate(16),
+		strings.Join(coords[0:3], " "))
+
+	coords, err = GetWordCoordinates(hash, 25, Layout1024)
+	assert.NoError(err)
+	assert.Equal(Layout1024.Coordinate(0) + " " + Layout1024.Coordinate(16) + " " + Layout1024.Coordinate(0x80),
+		strings.Join(coords[0:3], " "))
+
+	_, err = GetWordCoordinates(hash, 29, Layout512)
+	assert.Error(err)
+	_, err = GetWordCoordinates(hash, 26, Layout1024)
+	assert.Error(err)
+	_, err = GetWordCoordinates(hash, 4, &Layout{Letters: "A", ColSizes: []int{3}, ColsPerQuad: 1})
+	assert.Error(err)
+}
@@ -0,0 +1,125 @@
+package type1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/cruxic/mbcrypt/go"
+	"github.com/cruxic/passillion/go/util"
+	"strings"
+)
+
+/*
+One known-answer test.  See SelfTest().
+*/
+type selfTest struct {
+	name string
+	run func() error
+}
+
+func expectHex(what string, got []byte, want string) error {
+	if hex.EncodeToString(got) != want {
+		return fmt.Errorf("%s: got %x, want %s", what, got, want)
+	}
+	return nil
+}
+
+var gSelfTests = []selfTest{
+	{"HmacSha256", func() error {
+		//same vector as create.ts
+		return expectHex("HMAC", util.HmacSha256([]byte("The-Key"), []byte("The-Message")),
+			"9d77676b676ad963a2a581bdc8d78f1478ab2581014e40328cd9706bede5cec4")
+	}},
+
+	{"HmacCounterByteSource", func() error {
+		src := util.NewHmacCounterByteSource(util.ByteSequence(1, 32), 3)
+		block := make([]byte, 64)
+		if _, err := (&util.ByteSourceReader{Source: src}).Read(block); err != nil {
+			return err
+		}
+		return expectHex("blocks 0 and 1", block,
+			"2c8463ac51f796043dcd8edc7d3dda424569314980cdd762a562ef88c1718ca0" +
+			"3df609df0d17be5e19ba72218136e82546a973b1388c2e7beb95a9184355fe18")
+	}},
+
+	{"UnbiasedSmallInt", func() error {
+		//234 and above are discarded with n=26
+		src := &util.FixedByteSource{Bytes: []byte{234, 255, 100, 233}}
+		for _, want := range []int{22, 25} {
+			v, err := util.UnbiasedSmallInt(src, 26)
+			if err != nil {
+				return err
+			}
+			if v != want {
+				return fmt.Errorf("got %d, want %d", v, want)
+			}
+		}
+		return nil
+	}},
+
+	{"mbcrypt", func() error {
+		//same vector as typescript/mbcrypt_test.ts.  Salt is "abcdefghijklmnopqrstuu" in bcrypt-base64.
+		salt := []byte{0x71,0xd7,0x9f,0x82,0x18,0xa3,0x92,0x59,0xa7,0xa2,0x9a,0xab,0xb2,0xdb,0xaf,0xc3}
+		h, err := mbcrypt.Hash(4, []byte("Super Secret Password"), salt, 5)
+		if err != nil {
+			return err
+		}
+		return expectHex("4 threads cost 5", h, "a11b44ca410502c1ff194ebf45eb52a73d806c0e16ec0a8bd300185e897a7454")
+	}},
+
+	{"checkwords", func() error {
+		sha := sha256.New()
+		for _, word := range gCheckwords {
+			sha.Write([]byte(word))
+		}
+		return expectHex("list hash", sha.Sum(nil), "eb4388f6735a7778a49a8c2cefeaa429f1cadd2bb6a9dd0e777f9e21f07bbc9f")
+	}},
+
+	{"GetWordCoordinates", func() error {
+		sha := sha256.New()
+		for i := 0; i < 256; i += 32 {
+			coords, err := GetWordCoordinates(SiteHash(util.ByteSequence(byte(i), 32)), 32, StandardLayout)
+			if err != nil {
+				return err
+			}
+			sha.Write([]byte(strings.Join(coords, " ")))
+		}
+		return expectHex("all coordinates hash", sha.Sum(nil), "09c017822998970604a28fe870753b90567f5b4731626d0fc7ca9137f2867b85")
+	}},
+
+	{"CalcSiteHash", func() error {
+		h, err := CalcSiteHash("Super Secret", "example.com", "a")
+		if err != nil {
+			return err
+		}
+		return expectHex("hash", h, "0d7d37b83abbf8e0ff1cd2e2e943c25207f13040167ce68a672e7eb1c9ca15a3")
+	}},
+}
+
+/*
+Check the primitives behind the word coordinates against known answers:
+HMAC-SHA256, HmacCounterByteSource, UnbiasedSmallInt, mbcrypt, the
+checkword list, GetWordCoordinates and CalcSiteHash.  A broken build,
+compiler or CPU would otherwise silently give wrong coordinates.
+
+It takes about as long as one CalcSiteHash().  progress (if not nil) is
+called after each test.  Returns an error naming every test which failed.
+*/
+func SelfTest(progress func(name string, err error)) error {
+	var failed []string
+	for _, test := range gSelfTests {
+		err := test.run()
+		if progress != nil {
+			progress(test.name, err)
+		}
+		if err != nil {
+			failed = append(failed, test.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("self-test failed: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
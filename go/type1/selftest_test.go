@@ -0,0 +1,33 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SelfTest(t *testing.T) {
+	assert := assert.New(t)
+
+	var names []string
+	err := SelfTest(func(name string, err error) {
+		assert.NoError(err, name)
+		names = append(names, name)
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"HmacSha256", "HmacCounterByteSource", "UnbiasedSmallInt", "mbcrypt",
+		"checkwords", "GetWordCoordinates", "CalcSiteHash"}, names)
+
+	//a corrupted word list is detected
+	saved := gCheckwords[7]
+	gCheckwords[7] = "zzz"
+	defer func() { gCheckwords[7] = saved }()
+
+	var failed []string
+	err = SelfTest(func(name string, err error) {
+		if err != nil {
+			failed = append(failed, name)
+		}
+	})
+	assert.Equal([]string{"checkwords"}, failed)
+	assert.EqualError(err, "self-test failed: checkwords")
+}
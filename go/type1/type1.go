@@ -2,10 +2,14 @@ package type1
 
 import (
 	"github.com/cruxic/mbcrypt/go"
+	"github.com/cruxic/passillion/go/util"
+	"bytes"
 	"errors"
 	"crypto/sha256"
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 //32 byte hash
@@ -13,6 +17,12 @@ type SiteHash []byte
 
 const MinCoordPassLen = 10
 
+//The site hash is mbcrypt with 4 bcrypt threads, each cost 11.
+const (
+	KDFThreads = 4
+	KDFCost = 11
+)
+
 /*
 Convert ASCII A-Z to lower case a-z.  It does NOT touch other Unicode characters.
 This function is part of the normalization applied to the site name and
@@ -84,12 +94,33 @@ Secondly, since the "salt" includes the website, the rainbow table would have to
 Finally, the hash is expensive!  4 invokations of bcrypt, each cost 11.  This is
 effectively bcrypt 13.  Bcrypt is still one of the most GPU resistant hashes.
 */
-func makeSiteId(site, personalization string) []byte {
+func makeSiteId(site, personalization string, opt Options) []byte {
 	s := "passillion-type1\n" + NormalizeField(site) + "\n" + NormalizeField(personalization)
+
+	//Only appended when used so that existing coordinates do not change.
+	// NormalizeField removes newlines so this cannot collide with the above.
+	if opt.MixCardId && opt.CardId != "" {
+		s += "\ncard " + NormalizeField(opt.CardId)
+	}
+
+	//A secret pepper makes the salt unpredictable, preventing the
+	// precomputation discussed above.
+	if len(opt.Pepper) > 0 {
+		return util.HmacSha256(opt.Pepper, []byte(s))[0:mbcrypt.BcryptSaltLen]
+	}
+
 	h := sha256.Sum256([]byte(s))
 	return h[0:mbcrypt.BcryptSaltLen]
 }
 
+/*
+The bcrypt salt used for a site.  It identifies the normalized sitename,
+personalization, mixed card ID and pepper, eg for caching a SiteHash.
+*/
+func SiteId(sitename, personalization string, opt Options) []byte {
+	return makeSiteId(sitename, personalization, opt)
+}
+
 /*
 Return a checksum of the given password in the form of a 3 letter English word.
 */
@@ -123,11 +154,122 @@ func IsCorrectCheckword(password, checkword string) bool {
 	return CalcCheckword(password) == ToLowerAZ(checkword)
 }
 
+func trimSpaceRunes(s []rune) []rune {
+	for len(s) > 0 && unicode.IsSpace(s[0]) {
+		s = s[1:]
+	}
+	for len(s) > 0 && unicode.IsSpace(s[len(s)-1]) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+/*
+SplitCheckword for a password held in a rune slice (eg an input field) so
+that no string copy of it is made.  Strings cannot be erased.  The results
+are sub-slices of passwordWithCheckword.
+*/
+func SplitCheckwordRunes(passwordWithCheckword []rune) (pass, checkword []rune) {
+	s := trimSpaceRunes(passwordWithCheckword)
+	n := len(s)
+	if n > 3 {
+		pass = trimSpaceRunes(s[0:n-3])
+		checkword = s[n-3:]
+		if len(trimSpaceRunes(checkword)) == 3 {
+			return
+		}
+	}
+
+	//too short
+	return s, nil
+}
+
+/*
+IsCorrectCheckword for rune slices (see SplitCheckwordRunes).  The UTF-8
+copy hashed for the checkword is erased.
+*/
+func IsCorrectCheckwordRunes(password, checkword []rune) bool {
+	if len(checkword) != 3 {
+		return false
+	}
+
+	//UTF-8 encode like []byte(string(password)) but into an erasable slice
+	n := 0
+	for _, r := range password {
+		if size := utf8.RuneLen(r); size > 0 {
+			n += size
+		} else {
+			n += utf8.RuneLen(utf8.RuneError)  //what EncodeRune writes
+		}
+	}
+	raw := make([]byte, n)
+	i := 0
+	for _, r := range password {
+		i += utf8.EncodeRune(raw[i:], r)
+	}
+	hash := sha256.Sum256(raw)
+	util.Erase(raw)
+
+	expected := gCheckwords[int(hash[0])]
+	for i, r := range checkword {
+		if r >= 'A' && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		if r != rune(expected[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Optional inputs to CalcSiteHashWithOptions().  The zero value gives the
+same result as CalcSiteHash().
+*/
+type Options struct {
+	//Identifies the card the coordinates are meant for (eg a name like
+	// "Parents 2018" or the card's Fingerprint()).
+	CardId string
+
+	//Mix CardId into the hash so that each card gives different coordinates.
+	// Otherwise CardId is only displayed.
+	MixCardId bool
+
+	//Never repeat a word.  See GetWordCoordinatesWithOptions().
+	DistinctWords bool
+
+	//If set (see ReadKeyfile), the password is combined with the keyfile
+	// before hashing so the password and card alone are not enough.
+	KeyfileDigest []byte
+
+	//Optional machine-local secret (see the pepper package) mixed into the
+	// salt made by makeSiteId().
+	Pepper []byte
+}
+
+/*
+Label of the extra bytes drawn in DistinctWords mode.  A future change to
+the algorithm must use a new label.
+*/
+const distinctWordsLabel = "passillion-type1 distinct words v1"
+
 /*
 Hash the password with the site name using multiple bcrypt threads.
 The sitename and personalization parameters will be normalized with NormalizeField() before hashing.
 */
 func CalcSiteHash(password, sitename, personalization string) (SiteHash, error) {
+	return CalcSiteHashWithOptions(password, sitename, personalization, Options{})
+}
+
+/*
+Same as CalcSiteHash() with additional options.
+*/
+func CalcSiteHashWithOptions(password, sitename, personalization string, opt Options) (SiteHash, error) {
+	return calcSiteHash([]byte(password), sitename, personalization, opt)
+}
+
+//Same as CalcSiteHashWithOptions but the password stays a []byte so the caller can erase it.
+func calcSiteHash(password []byte, sitename, personalization string, opt Options) (SiteHash, error) {
 	var hash SiteHash
 
 	if len(password) < MinCoordPassLen {
@@ -138,10 +280,15 @@ func CalcSiteHash(password, sitename, personalization string) (SiteHash, error)
 		return hash, errors.New("sitename cannot be empty")
 	}
 
-	siteId := makeSiteId(sitename, personalization)
+	siteId := makeSiteId(sitename, personalization, opt)
 
-	//4 bcrypt threads, each cost 11
-	h, err := mbcrypt.Hash(4, []byte(password), siteId, 11)
+	pass := password
+	if len(opt.KeyfileDigest) > 0 {
+		pass = combineKeyfile(pass, opt.KeyfileDigest)
+		defer util.Erase(pass)
+	}
+
+	h, err := mbcrypt.Hash(KDFThreads, pass, siteId, KDFCost)
 	if err != nil {
 		return hash, err
 	}
@@ -149,87 +296,81 @@ func CalcSiteHash(password, sitename, personalization string) (SiteHash, error)
 	return SiteHash(h), nil
 }
 
-//The twelve column header letters as a string.
+//The twelve column header letters of StandardLayout.
 const ColumnLetters = "ABCDEFTUVXYZ"
 
-func getColSize(columnIndex int) int {
-	//First three columns and very last colum have 20.
-	//All others are 22.
-	if columnIndex < 3 || columnIndex == 11 {
-		return 20
-	} else {
-		return 22
+/*
+Same as GetWordCoordinates() with options.  Only DistinctWords is relevant.
+
+With DistinctWords, every word which repeats an earlier one is replaced by
+words drawn from an HmacCounterByteSource keyed with the SiteHash (and
+distinctWordsLabel) until it is unique.  Coordinates without repeats are
+identical to GetWordCoordinates().
+*/
+func GetWordCoordinatesWithOptions(hash SiteHash, nWords int, layout *Layout, opt Options) ([]string, error) {
+	coords, err := GetWordCoordinates(hash, nWords, layout)
+	if err != nil || !opt.DistinctWords {
+		return coords, err
 	}
-}
 
-type _ColAndWordNum struct {
-	//Column index (0-11). Corresponds to 'ColumnLetters'
-	ColumnIndex int
+	//otherwise the loop below would never find an unused word
+	if nWords > layout.NumWords() {
+		return nil, errors.New("more distinct words requested than the layout has")
+	}
 
-	//word number within the column. Note: word numbers are unique
-	//  within the entire quadrant (3 columns).
-	WordNumber int
-}
+	var extra *util.BitReader
+	key := util.HmacSha256(hash, []byte(distinctWordsLabel))
+	defer util.Erase(key)
 
-/*
-Given a word index (0-255) get the column it belongs in (0-11) and
-the word number within that column.  Note: word numbers are unique
-within the entire quadrant (3 columns).
-*/
-func getColumnIndexAndWordNumber(wordIndex int) _ColAndWordNum {
-	if wordIndex < 0 || wordIndex > 255 {
-		panic("wordIndex out of range");
-	}
-
-	//For each column...
-	k := 0
-	numInQuad := 1
-	for col := 0; col < 12; col++ {
-		//reset numInQuad when starting new quadrant
-		if col % 3 == 0 {
-			numInQuad = 1
-		}
+	used := make(map[string]bool)
+	for i := range coords {
+		for used[coords[i]] {
+			if extra == nil {
+				source := util.NewHmacCounterByteSource(key, 0xFFFFFFFF)
+				extra = util.NewBitReader(&util.ByteSourceReader{Source: source})
+			}
 
-		colSize := getColSize(col)
-		k += colSize
-		if wordIndex < k {
-			k -= colSize
-			return _ColAndWordNum{
-				ColumnIndex: col,
-				WordNumber: numInQuad + (wordIndex - k),
+			wordIndex, err := extra.ReadBits(layout.BitsPerWord())
+			if err != nil {
+				return nil, err
 			}
+			coords[i] = layout.Coordinate(int(wordIndex))
 		}
-
-		numInQuad += colSize
+		used[coords[i]] = true
 	}
 
-	//will never reach here
-	panic("assert fail")
+	return coords, nil
 }
 
-
 /*
-Given the SiteHash, get word coordinates (eg "C13", "X9", ...).
+Given the SiteHash, get word coordinates (eg "C13", "X9", ...) on a card
+with the given layout.  Each word consumes layout.BitsPerWord() bits of the
+hash so no modulo bias is introduced.
 */
-func GetWordCoordinates(hash SiteHash, nWords int) ([]string, error) {
+func GetWordCoordinates(hash SiteHash, nWords int, layout *Layout) ([]string, error) {
 	if len(hash) != 32 {
 		return nil, errors.New("wrong hash length")
 	}
 
-	if nWords < 1 || nWords > len(hash) {
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
+
+	bits := layout.BitsPerWord()
+	if nWords < 1 || nWords > len(hash) * 8 / bits {
 		return nil, errors.New("nWords out of range")
 	}
 
 	coords := make([]string, nWords)
 
+	br := util.NewBitReader(bytes.NewReader(hash))
 	for i := 0; i < nWords; i++ {
-		wordIndex := int(hash[i])  //0-255
-		//Note: no modulo bias since wordIndex is exactly 8 bits.
-
-		cw := getColumnIndexAndWordNumber(wordIndex)
+		wordIndex, err := br.ReadBits(bits)
+		if err != nil {
+			return nil, err
+		}
 
-		letter := ColumnLetters[cw.ColumnIndex:cw.ColumnIndex+1]
-		coords[i] = fmt.Sprintf("%s%d", letter, cw.WordNumber)
+		coords[i] = layout.Coordinate(int(wordIndex))
 	}
 
 	return coords, nil
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"strings"
 	"crypto/sha256"
+	"github.com/cruxic/passillion/go/util"
 )
 
 func Test_ToLowerAZ(t *testing.T) {
@@ -78,6 +79,24 @@ func Test_SplitCheckword(t *testing.T) {
 	assert.Equal("", b)
 }
 
+func Test_CheckwordRunes(t *testing.T) {
+	assert := assert.New(t)
+
+	//must agree with the string versions
+	for _, s := range []string{"Hello Worldabc", " \tHello World \t  abc \t\n", "Hello World ab",
+		"Hi", "", "Hello WorldPET", "Hello Worlflog", "Hello Worlf log", "Grüße dich" + CalcCheckword("Grüße dich"),
+		"Grüße dich lo", "Hello World" + string([]rune{0xD800}) + "pet"} {
+		pass, checkword := SplitCheckword(s)
+		passR, checkwordR := SplitCheckwordRunes([]rune(s))
+		assert.Equal(pass, string(passR), s)
+		assert.Equal(checkword, string(checkwordR), s)
+		assert.Equal(IsCorrectCheckword(pass, checkword), IsCorrectCheckwordRunes(passR, checkwordR), s)
+	}
+
+	assert.True(IsCorrectCheckwordRunes([]rune("Hello World"), []rune("pEt")))
+	assert.False(IsCorrectCheckwordRunes([]rune("Hello World"), []rune("pe")))
+}
+
 func Test_CalcSiteHash(t *testing.T) {
 	assert := assert.New(t)
 
@@ -106,6 +125,37 @@ func Test_CalcSiteHash(t *testing.T) {
 	assert.Equal("0d7d37b83abbf8e0ff1cd2e2e943c25207f13040167ce68a672e7eb1c9ca15a3", hex.EncodeToString([]byte(siteha)))
 }
 
+func Test_CalcSiteHashWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	const plain = "0d7d37b83abbf8e0ff1cd2e2e943c25207f13040167ce68a672e7eb1c9ca15a3"
+
+	//Card ID which is only displayed does not change the hash
+	siteha, err := CalcSiteHashWithOptions("Super Secret", "example.com", "a", Options{CardId: "fog-ram"})
+	assert.NoError(err)
+	assert.Equal(plain, hex.EncodeToString([]byte(siteha)))
+
+	siteha, err = CalcSiteHashWithOptions("Super Secret", "example.com", "a", Options{MixCardId: true})
+	assert.NoError(err)
+	assert.Equal(plain, hex.EncodeToString([]byte(siteha)))
+
+	siteha, err = CalcSiteHashWithOptions("Super Secret", "example.com", "a", Options{CardId: "fog-ram", MixCardId: true})
+	assert.NoError(err)
+	assert.Equal("16272d19a00ac8bd0da3b7b5234ebfa545b49899f3a2c8441c2fcf6847d91bac", hex.EncodeToString([]byte(siteha)))
+
+	//card ID is normalized
+	siteha2, err := CalcSiteHashWithOptions("Super Secret", "example.com", "a", Options{CardId: " FOG-ram\n", MixCardId: true})
+	assert.NoError(err)
+	assert.Equal(siteha, siteha2)
+
+	//pepper
+	siteha, err = CalcSiteHashWithOptions("Super Secret", "example.com", "a", Options{Pepper: []byte("pepper")})
+	assert.NoError(err)
+	assert.Equal("95fc50f9bc19419d2fb90fb2d75bd95fd1261fc631fefccbf127268f889df103", hex.EncodeToString([]byte(siteha)))
+	assert.Equal(util.HmacSha256([]byte("pepper"), []byte("passillion-type1\nexample.com\na"))[0:16],
+		makeSiteId("example.com", "a", Options{Pepper: []byte("pepper")}))
+}
+
 func makeSeq(start, count int) []byte {
 	seq := make([]byte, count)
 	for i := 0; i < count; i++ {
@@ -119,7 +169,7 @@ func Test_GetWordCoordinates(t *testing.T) {
 	assert := assert.New(t)
 
 	//first 4
-	coords, err := GetWordCoordinates(SiteHash(makeSeq(0, 32)), 4)
+	coords, err := GetWordCoordinates(SiteHash(makeSeq(0, 32)), 4, StandardLayout)
 	assert.NoError(err)
 	assert.Equal(4, len(coords))
 	s := strings.Join(coords, " ")
@@ -129,7 +179,7 @@ func Test_GetWordCoordinates(t *testing.T) {
 	sha := sha256.New()
 	all := make([]string, 0, 256)
 	for i := 0; i < 256; i += 32 {
-		coords, err = GetWordCoordinates(SiteHash(makeSeq(i, 32)), 32)
+		coords, err = GetWordCoordinates(SiteHash(makeSeq(i, 32)), 32, StandardLayout)
 		assert.NoError(err)
 		all = append(all, coords...)
 		sha.Write([]byte(strings.Join(coords, " ")))
@@ -169,3 +219,59 @@ func Test_GetWordCoordinates(t *testing.T) {
 	assert.Equal("Z45", all[236])
 	assert.Equal("Z64", all[255])
 }
+
+func Test_GetWordCoordinatesDistinct(t *testing.T) {
+	assert := assert.New(t)
+
+	opt := Options{DistinctWords: true}
+
+	//no repeats: same as before
+	hash := SiteHash(makeSeq(0, 32))
+	coords, err := GetWordCoordinatesWithOptions(hash, 32, StandardLayout, opt)
+	assert.NoError(err)
+	plain, _ := GetWordCoordinates(hash, 32, StandardLayout)
+	assert.Equal(plain, coords)
+
+	//option off: repeats allowed
+	hash = SiteHash(make([]byte, 32))
+	coords, err = GetWordCoordinatesWithOptions(hash, 4, StandardLayout, Options{})
+	assert.NoError(err)
+	assert.Equal("A1 A1 A1 A1", strings.Join(coords, " "))
+
+	coords, err = GetWordCoordinatesWithOptions(hash, 4, StandardLayout, opt)
+	assert.NoError(err)
+	assert.Equal("A1 D1 U31 B36", strings.Join(coords, " "))
+
+	//the first occurrence is kept
+	hash[1] = 5
+	hash[2] = 5
+	coords, err = GetWordCoordinatesWithOptions(hash, 3, StandardLayout, opt)
+	assert.NoError(err)
+	assert.Equal("A1", coords[0])
+	assert.Equal("A6", coords[1])
+	assert.NotEqual("A6", coords[2])
+	assert.NotEqual("A1", coords[2])
+
+	//more words than a small layout has
+	tiny := &Layout{Letters: "AB", ColSizes: []int{2, 2}, ColsPerQuad: 1}
+	coords, err = GetWordCoordinatesWithOptions(hash, 4, tiny, opt)
+	assert.NoError(err)
+	assert.Equal(4, len(coords))
+	_, err = GetWordCoordinatesWithOptions(hash, 5, tiny, opt)
+	assert.Error(err)
+	_, err = GetWordCoordinatesWithOptions(hash, 5, tiny, Options{})
+	assert.NoError(err)
+
+	//all 32 distinct on every layout
+	hash = SiteHash(make([]byte, 32))
+	for _, layout := range gLayouts {
+		n := 256 / layout.BitsPerWord()
+		coords, err = GetWordCoordinatesWithOptions(hash, n, layout, opt)
+		assert.NoError(err)
+		m := make(map[string]bool)
+		for _, c := range coords {
+			m[c] = true
+		}
+		assert.Equal(n, len(m))
+	}
+}
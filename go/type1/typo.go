@@ -0,0 +1,279 @@
+package type1
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+A category of single-keystroke error which could explain why the checkword
+did not match.
+*/
+type TypoKind int
+
+const (
+	TypoCapsLock TypoKind = iota + 1
+	TypoTransposition
+	TypoAdjacentKey
+	TypoDroppedChar
+	TypoDuplicatedChar
+)
+
+func (k TypoKind) String() string {
+	switch k {
+	case TypoCapsLock:
+		return "Caps Lock appears to be on"
+	case TypoTransposition:
+		return "two neighboring characters appear to be swapped"
+	case TypoAdjacentKey:
+		return "a neighboring key appears to have been pressed"
+	case TypoDroppedChar:
+		return "a repeated character appears to be missing"
+	case TypoDuplicatedChar:
+		return "a character appears to be typed twice"
+	default:
+		return "unknown typo"
+	}
+}
+
+/*
+The outcome of DiagnoseTypo for one category of error.
+*/
+type TypoHint struct {
+	Kind TypoKind
+
+	//How many variations of the entered text were tried for this category
+	Candidates int
+
+	//For TypoAdjacentKey: the keyboard layout whose neighboring keys
+	// explain the checkword (eg "qwerty").  Empty for the other kinds.
+	Keyboard string
+
+	//True if it is unlikely that the checkword matched by chance.
+	// The checkword is only 8 bits so with enough candidates one of
+	// them will match by pure luck.
+	Likely bool
+}
+
+//Keyboard rows (unshifted, shifted) used to find adjacent keys.
+type keyboardLayout [][2]string
+
+var gLayoutQWERTY = keyboardLayout{
+	{"`1234567890-=", "~!@#$%^&*()_+"},
+	{"qwertyuiop[]\\", "QWERTYUIOP{}|"},
+	{"asdfghjkl;'", "ASDFGHJKL:\""},
+	{"zxcvbnm,./", "ZXCVBNM<>?"},
+}
+
+var gLayoutAZERTY = keyboardLayout{
+	{"²&é\"'(-è_çà)=", "²1234567890°+"},
+	{"azertyuiop^$", "AZERTYUIOP¨£"},
+	{"qsdfghjklmù*", "QSDFGHJKLM%µ"},
+	{"<wxcvbn,;:!", ">WXCVBN?./§"},
+}
+
+var gLayoutDvorak = keyboardLayout{
+	{"`1234567890[]", "~!@#$%^&*(){}"},
+	{"',.pyfgcrl/=\\", "\"<>PYFGCRL?+|"},
+	{"aoeuidhtns-", "AOEUIDHTNS_"},
+	{";qjkxbmwvz", ":QJKXBMWVZ"},
+}
+
+//Layouts tried by DiagnoseTypo when no keyboard is given, in order.
+var gKeyboardNames = []string{"qwerty", "azerty", "dvorak"}
+
+//Supported names for DiagnoseTypo's keyboard parameter.
+var gKeyboards = map[string]keyboardLayout{
+	"qwerty": gLayoutQWERTY,
+	"azerty": gLayoutAZERTY,
+	"dvorak": gLayoutDvorak,
+}
+
+/*
+Return the keys which surround the given key.  Each row is assumed to be
+staggered half a key to the right of the row above it.
+*/
+func (self keyboardLayout) neighbors(key rune) []rune {
+	var res []rune
+
+	for r, row := range self {
+		for shift := 0; shift < 2; shift++ {
+			keys := []rune(row[shift])
+			for i, k := range keys {
+				if k != key {
+					continue
+				}
+
+				add := func(otherRow, col int) {
+					if otherRow < 0 || otherRow >= len(self) {
+						return
+					}
+					other := []rune(self[otherRow][shift])
+					if col >= 0 && col < len(other) {
+						res = append(res, other[col])
+					}
+				}
+
+				add(r, i-1)
+				add(r, i+1)
+				add(r-1, i)
+				add(r-1, i+1)
+				add(r+1, i-1)
+				add(r+1, i)
+			}
+		}
+	}
+
+	return res
+}
+
+//Swap the case of ASCII A-Z and a-z as if Caps Lock were on.
+func invertCaseAZ(s string) string {
+	raw := []byte(s)
+	const delta = byte('a' - 'A')
+
+	for i, b := range raw {
+		if b >= 'A' && b <= 'Z' {
+			raw[i] = b + delta
+		} else if b >= 'a' && b <= 'z' {
+			raw[i] = b - delta
+		}
+	}
+
+	return string(raw)
+}
+
+func matchesCheckword(candidate string) bool {
+	pass, checkword := SplitCheckword(candidate)
+	return len(checkword) > 0 && IsCorrectCheckword(pass, checkword)
+}
+
+/*
+Call fn with every variation of the entered text which could be the
+intended text if the given kind of typo was made.  keyboard is only used
+for TypoAdjacentKey.
+*/
+func enumerateTypos(kind TypoKind, entered []rune, keyboard keyboardLayout, fn func(candidate string)) {
+	n := len(entered)
+
+	switch kind {
+	case TypoCapsLock:
+		fn(invertCaseAZ(string(entered)))
+
+	case TypoTransposition:
+		for i := 0; i+1 < n; i++ {
+			if entered[i] == entered[i+1] {
+				continue
+			}
+			c := append([]rune{}, entered...)
+			c[i], c[i+1] = c[i+1], c[i]
+			fn(string(c))
+		}
+
+	case TypoAdjacentKey:
+		for i := 0; i < n; i++ {
+			for _, k := range keyboard.neighbors(entered[i]) {
+				c := append([]rune{}, entered...)
+				c[i] = k
+				fn(string(c))
+			}
+		}
+
+	case TypoDroppedChar:
+		//Only consider a missed repeat of a neighboring character (eg "leter" for "letter")
+		// because any other character would yield too many candidates to be useful.
+		for i := 0; i < n; i++ {
+			if i > 0 && entered[i-1] == entered[i] {
+				continue
+			}
+			c := make([]rune, 0, n+1)
+			c = append(c, entered[:i+1]...)
+			c = append(c, entered[i:]...)
+			fn(string(c))
+		}
+
+	case TypoDuplicatedChar:
+		for i := 1; i < n; i++ {
+			if entered[i-1] != entered[i] {
+				continue
+			}
+			c := make([]rune, 0, n-1)
+			c = append(c, entered[:i]...)
+			c = append(c, entered[i+1:]...)
+			fn(string(c))
+		}
+	}
+}
+
+/*
+Guess which single-keystroke error caused the checkword of the entered
+text (password followed by checkword) to be wrong.  Each category of typo
+is tried and a hint is returned for every category having a variation whose
+checkword matches.  The variations themselves are never returned so that
+the caller cannot accidentally display the password.
+
+keyboard is "qwerty", "azerty" or "dvorak".  If empty, each of the three
+layouts is tried separately and every layout which explains the checkword
+gets its own adjacent key hint.
+
+Returns nil if the checkword is already correct or nothing matched.
+*/
+func DiagnoseTypo(passwordWithCheckword, keyboard string) ([]TypoHint, error) {
+	keyboardNames := gKeyboardNames
+	if len(keyboard) > 0 {
+		name := ToLowerAZ(keyboard)
+		if _, ok := gKeyboards[name]; !ok {
+			return nil, fmt.Errorf("unknown keyboard layout \"%s\"", keyboard)
+		}
+		keyboardNames = []string{name}
+	}
+
+	entered := strings.TrimSpace(passwordWithCheckword)
+	if matchesCheckword(entered) {
+		return nil, nil
+	}
+
+	//Probability that a random candidate matches the 8bit checkword.
+	const chance = 1.0 / float64(len(gCheckwords))
+
+	var hints []TypoHint
+	runes := []rune(entered)
+
+	//nLayouts: how many layouts this kind is tried on.  Each extra layout is
+	// another chance for an unrelated candidate to match.
+	try := func(kind TypoKind, keyboardName string, nLayouts int) {
+		count := 0
+		found := false
+		enumerateTypos(kind, runes, gKeyboards[keyboardName], func(candidate string) {
+			count++
+			if !found && matchesCheckword(candidate) {
+				found = true
+			}
+		})
+
+		//A hint is useless if a chance match is expected anyway
+		expected := float64(count) * chance * float64(nLayouts)
+		if found && expected < 0.5 {
+			hints = append(hints, TypoHint{
+				Kind: kind,
+				Candidates: count,
+				Keyboard: keyboardName,
+				Likely: expected < 0.1,
+			})
+		}
+	}
+
+	for _, kind := range []TypoKind{TypoCapsLock, TypoTransposition, TypoAdjacentKey,
+		TypoDroppedChar, TypoDuplicatedChar} {
+		if kind == TypoAdjacentKey {
+			//Pooling the layouts would triple the candidates and bury the hint
+			for _, name := range keyboardNames {
+				try(kind, name, len(keyboardNames))
+			}
+		} else {
+			try(kind, "", 1)
+		}
+	}
+
+	return hints, nil
+}
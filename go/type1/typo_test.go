@@ -0,0 +1,124 @@
+package type1
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func hasHint(hints []TypoHint, kind TypoKind) bool {
+	for _, h := range hints {
+		if h.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func Test_keyboardNeighbors(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("fhtyvb", string(gLayoutQWERTY.neighbors('g')))
+	assert.Equal("FHTYVB", string(gLayoutQWERTY.neighbors('G')))
+	assert.Equal("sqwz", string(gLayoutQWERTY.neighbors('a')))
+	assert.Equal("ihfgxb", string(gLayoutDvorak.neighbors('d')))
+	assert.Nil(gLayoutQWERTY.neighbors('Γ'))
+}
+
+func Test_invertCaseAZ(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("sUPER sECRET 123 Γ", invertCaseAZ("Super Secret 123 Γ"))
+}
+
+func Test_DiagnoseTypo(t *testing.T) {
+	assert := assert.New(t)
+
+	pass := "Super Secret"
+	good := pass + CalcCheckword(pass)
+
+	diagnose := func(entered string) []TypoHint {
+		hints, err := DiagnoseTypo(entered, "qwerty")
+		assert.NoError(err)
+		return hints
+	}
+
+	//nothing wrong
+	assert.Nil(diagnose(good))
+
+	//Caps Lock
+	hints := diagnose(invertCaseAZ(good))
+	assert.True(hasHint(hints, TypoCapsLock))
+	assert.True(hints[0].Likely)
+	assert.Equal("Caps Lock appears to be on", hints[0].Kind.String())
+
+	//swapped
+	hints = diagnose("Supre Secret" + CalcCheckword(pass))
+	assert.True(hasHint(hints, TypoTransposition))
+
+	//adjacent key (r -> t)
+	hints = diagnose("Supet Secret" + CalcCheckword(pass))
+	assert.True(hasHint(hints, TypoAdjacentKey))
+
+	//missed double letter
+	pass = "Bookkeeper lives"
+	hints = diagnose("Bokkeeper lives" + CalcCheckword(pass))
+	assert.True(hasHint(hints, TypoDroppedChar))
+
+	//duplicate letter
+	hints = diagnose("Bookkeeperr lives" + CalcCheckword(pass))
+	assert.True(hasHint(hints, TypoDuplicatedChar))
+
+	//typo in the checkword itself
+	cw := []rune(CalcCheckword(pass))
+	cw[1], cw[2] = cw[2], cw[1]
+	if cw[1] != cw[2] {
+		hints = diagnose(pass + string(cw))
+		assert.True(hasHint(hints, TypoTransposition))
+	}
+}
+
+func Test_DiagnoseTypo_keyboard(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := DiagnoseTypo("Super Secretabc", "colemak")
+	assert.Error(err)
+
+	_, err = DiagnoseTypo("Super Secretabc", "Dvorak")
+	assert.NoError(err)
+
+	//All layouts
+	_, err = DiagnoseTypo("Super Secretabc", "")
+	assert.NoError(err)
+
+	//Each layout is scored on its own (r -> t is adjacent on qwerty and
+	// azerty) but trying three layouts triples the chance of a coincidence
+	adjacent := func(hints []TypoHint) []TypoHint {
+		var res []TypoHint
+		for _, h := range hints {
+			if h.Kind == TypoAdjacentKey {
+				res = append(res, h)
+			} else {
+				assert.Equal("", h.Keyboard)
+			}
+		}
+		return res
+	}
+
+	entered := "Supet" + CalcCheckword("Super")
+	qwerty, err := DiagnoseTypo(entered, "qwerty")
+	assert.NoError(err)
+	all, err := DiagnoseTypo(entered, "")
+	assert.NoError(err)
+	assert.Equal(1, len(adjacent(qwerty)))
+	assert.True(len(adjacent(all)) >= 1)
+	assert.Equal("qwerty", adjacent(all)[0].Keyboard)
+	assert.Equal(adjacent(qwerty)[0].Candidates, adjacent(all)[0].Candidates)
+
+	//82 candidates: 0.32 chance matches expected on one layout, 0.96 on three
+	entered = "Supet Secret" + CalcCheckword("Super Secret")
+	qwerty, err = DiagnoseTypo(entered, "qwerty")
+	assert.NoError(err)
+	all, err = DiagnoseTypo(entered, "")
+	assert.NoError(err)
+	assert.Equal(1, len(adjacent(qwerty)))
+	assert.Equal(0, len(adjacent(all)))
+}
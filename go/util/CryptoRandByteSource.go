@@ -0,0 +1,151 @@
+package util
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+/*
+Assumed min-entropy (bits) of each byte from the operating system.  This is
+far below the true value so that a healthy source practically never trips
+the health tests below (false alarm probability 2^-20 or less per test).
+*/
+const cryptoRandAssumedEntropy = 4
+
+/*
+Repetition Count Test cutoff (SP 800-90B 4.4.1): 1 + ceil(20 / H).
+This many identical bytes in a row means the source is stuck.
+*/
+const repetitionCountCutoff = 1 + (20 + cryptoRandAssumedEntropy - 1) / cryptoRandAssumedEntropy
+
+/*
+Adaptive Proportion Test (SP 800-90B 4.4.2) window size and cutoff.
+The cutoff is 1 + CRITBINOM(512, 2^-H, 1 - 2^-20) with H=4.
+*/
+const adaptiveProportionWindow = 512
+const adaptiveProportionCutoff = 62
+
+var ErrEntropyHealthTest = errors.New("CryptoRandByteSource: entropy health test failed")
+
+/*
+A ByteSource backed by crypto/rand (operating system entropy).  Bytes are
+read in blocks and erased from the buffer as soon as they are returned.
+
+Every byte passes through continuous health tests in the style of
+NIST SP 800-90B.  If a test fails the source fails closed: the buffer is
+erased and every subsequent NextByte() returns ErrEntropyHealthTest.
+*/
+type CryptoRandByteSource struct {
+	reader io.Reader
+
+	block []byte
+	blockOffset int
+
+	//Repetition Count Test
+	rctLast byte
+	rctCount int
+
+	//Adaptive Proportion Test
+	aptFirst byte
+	aptCount int
+	aptSamples int
+
+	failed error
+}
+
+func NewCryptoRandByteSource() *CryptoRandByteSource {
+	return newCryptoRandByteSourceFrom(rand.Reader, 64)
+}
+
+//For unit testing with a fake entropy source.
+func newCryptoRandByteSourceFrom(reader io.Reader, blockSize int) *CryptoRandByteSource {
+	return &CryptoRandByteSource{
+		reader: reader,
+		block: make([]byte, blockSize),
+		blockOffset: blockSize,
+	}
+}
+
+/*
+Feed one sample through the health tests.  Returns false if the source
+must be considered broken.
+*/
+func (self *CryptoRandByteSource) healthy(b byte) bool {
+	//Repetition Count Test
+	if self.rctCount > 0 && b == self.rctLast {
+		self.rctCount++
+		if self.rctCount >= repetitionCountCutoff {
+			return false
+		}
+	} else {
+		self.rctLast = b
+		self.rctCount = 1
+	}
+
+	//Adaptive Proportion Test
+	if self.aptSamples == 0 {
+		self.aptFirst = b
+		self.aptCount = 1
+	} else if b == self.aptFirst {
+		self.aptCount++
+		if self.aptCount >= adaptiveProportionCutoff {
+			return false
+		}
+	}
+
+	self.aptSamples++
+	if self.aptSamples >= adaptiveProportionWindow {
+		self.aptSamples = 0
+	}
+
+	return true
+}
+
+func (self *CryptoRandByteSource) fill() error {
+	Erase(self.block)
+	self.blockOffset = 0
+
+	_, err := io.ReadFull(self.reader, self.block)
+	if err != nil {
+		Erase(self.block)
+		return err
+	}
+
+	for _, b := range self.block {
+		if !self.healthy(b) {
+			return ErrEntropyHealthTest
+		}
+	}
+
+	return nil
+}
+
+func (self *CryptoRandByteSource) NextByte() (byte, error) {
+	if self.failed != nil {
+		return 0, self.failed
+	}
+
+	if self.blockOffset >= len(self.block) {
+		if err := self.fill(); err != nil {
+			self.failed = err
+			self.Erase()
+			return 0, err
+		}
+	}
+
+	b := self.block[self.blockOffset]
+	self.block[self.blockOffset] = 0
+	self.blockOffset++
+
+	return b, nil
+}
+
+/*
+Zero the unused random bytes remaining in the buffer.  The source remains
+usable; the next call to NextByte() reads a fresh block.
+*/
+func (self *CryptoRandByteSource) Erase() {
+	Erase(self.block)
+	self.blockOffset = len(self.block)
+}
@@ -0,0 +1,108 @@
+package util
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"bytes"
+	"errors"
+)
+
+func Test_CryptoRandByteSource(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(6, repetitionCountCutoff)
+
+	src := NewCryptoRandByteSource()
+	counts := make(map[byte]int)
+	for i := 0; i < 100000; i++ {
+		b, err := src.NextByte()
+		if !assert.NoError(err) {
+			return
+		}
+		counts[b]++
+	}
+
+	//all values will almost certainly appear
+	assert.Equal(256, len(counts))
+
+	//consumed bytes are erased from the buffer
+	assert.Equal(make([]byte, src.blockOffset), src.block[0:src.blockOffset])
+
+	src.Erase()
+	assert.Equal(make([]byte, len(src.block)), src.block)
+	_, err := src.NextByte()
+	assert.NoError(err)
+}
+
+func Test_CryptoRandByteSource_repetition(t *testing.T) {
+	assert := assert.New(t)
+
+	//5 identical bytes are tolerated
+	input := append(ByteSequence(0, 16), 7, 7, 7, 7, 7, 8)
+	src := newCryptoRandByteSourceFrom(bytes.NewReader(input), len(input))
+	for i := range input {
+		b, err := src.NextByte()
+		assert.NoError(err)
+		assert.Equal(input[i], b)
+	}
+
+	//6 are not
+	input = append(ByteSequence(0, 16), 7, 7, 7, 7, 7, 7)
+	src = newCryptoRandByteSourceFrom(bytes.NewReader(input), len(input))
+	_, err := src.NextByte()
+	assert.Equal(ErrEntropyHealthTest, err)
+	assert.Equal(make([]byte, len(input)), src.block)
+
+	//fails closed
+	_, err = src.NextByte()
+	assert.Equal(ErrEntropyHealthTest, err)
+}
+
+func Test_CryptoRandByteSource_proportion(t *testing.T) {
+	assert := assert.New(t)
+
+	//Every 8th byte is zero: 64 zeros in a window of 512
+	biased := make([]byte, 1024)
+	for i := range biased {
+		if i % 8 == 0 {
+			biased[i] = 0
+		} else {
+			biased[i] = byte(i)
+		}
+	}
+
+	src := newCryptoRandByteSourceFrom(bytes.NewReader(biased), 512)
+	_, err := src.NextByte()
+	assert.Equal(ErrEntropyHealthTest, err)
+
+	//Every 16th byte is zero: 32 zeros per window is fine
+	for i := range biased {
+		if i % 16 == 0 {
+			biased[i] = 0
+		} else {
+			biased[i] = byte(i)
+		}
+	}
+
+	src = newCryptoRandByteSourceFrom(bytes.NewReader(biased), 512)
+	for i := 0; i < 1024; i++ {
+		_, err = src.NextByte()
+		assert.NoError(err)
+	}
+}
+
+type errorReader struct{}
+
+func (self errorReader) Read(p []byte) (int, error) {
+	return 0, errors.New("broken")
+}
+
+func Test_CryptoRandByteSource_readError(t *testing.T) {
+	assert := assert.New(t)
+
+	src := newCryptoRandByteSourceFrom(errorReader{}, 32)
+	_, err := src.NextByte()
+	assert.EqualError(err, "broken")
+	_, err = src.NextByte()
+	assert.EqualError(err, "broken")
+}
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+)
+
+/*
+The directory for user data files: $XDG_DATA_HOME, or ~/.local/share when
+XDG_DATA_HOME is not set.  The standard library only has
+os.UserConfigDir and os.UserCacheDir.
+*/
+func UserDataDir() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"os"
+)
+
+func Test_UserDataDir(t *testing.T) {
+	assert := assert.New(t)
+
+	defer os.Setenv("XDG_DATA_HOME", os.Getenv("XDG_DATA_HOME"))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+
+	os.Setenv("XDG_DATA_HOME", "/x/data")
+	dir, err := UserDataDir()
+	assert.NoError(err)
+	assert.Equal("/x/data", dir)
+
+	os.Setenv("XDG_DATA_HOME", "")
+	os.Setenv("HOME", "/home/me")
+	dir, err = UserDataDir()
+	assert.NoError(err)
+	assert.Equal("/home/me/.local/share", dir)
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"errors"
+	"math/big"
 )
 
 /**Request a single byte at a time from some abstract source.*/
@@ -100,6 +101,84 @@ func UnbiasedSmallInt(source ByteSource, n int) (int, error) {
 	}
 }
 
+/**Create a random integer from [0, n) for any positive n (not limited to 256).
+Reads the minimal number of bits needed to represent n-1 and rejects values >= n
+so there is no modulo bias.
+
+Every attempt starts reading from a fresh byte so leftover bits are discarded.
+
+Returns error if the random source is exhausted or n is not positive.
+*/
+func UnbiasedInt(source ByteSource, n int) (int, error) {
+	if n <= 0 || uint64(n) > (1 << 32) {
+		return -1, errors.New("UnbiasedInt: n out of range")
+	}
+
+	//number of bits needed for n-1
+	nBits := 0
+	for (1 << uint(nBits)) < n {
+		nBits++
+	}
+
+	if nBits == 0 {
+		return 0, nil
+	}
+
+	for {
+		br := NewBitReader(&ByteSourceReader{Source: source})
+		r, err := br.ReadBits(nBits)
+		if err != nil {
+			return -1, err
+		}
+
+		if uint64(r) < uint64(n) {
+			return int(r), nil
+		}
+	}
+}
+
+/**Like UnbiasedInt but for arbitrarily large n.  Returns a random integer
+from [0, n) without modulo bias.  Every attempt starts reading from a fresh byte.
+*/
+func UnbiasedBigInt(source ByteSource, n *big.Int) (*big.Int, error) {
+	if n.Sign() <= 0 {
+		return nil, errors.New("UnbiasedBigInt: n out of range")
+	}
+
+	//number of bits needed for n-1
+	max := new(big.Int).Sub(n, big.NewInt(1))
+	nBits := max.BitLen()
+
+	r := new(big.Int)
+	if nBits == 0 {
+		return r, nil
+	}
+
+	for {
+		br := NewBitReader(&ByteSourceReader{Source: source})
+		r.SetInt64(0)
+		for remain := nBits; remain > 0; {
+			chunk := remain
+			if chunk > 32 {
+				chunk = 32
+			}
+
+			bits, err := br.ReadBits(chunk)
+			if err != nil {
+				return nil, err
+			}
+
+			r.Lsh(r, uint(chunk))
+			r.Or(r, new(big.Int).SetUint64(uint64(bits)))
+			remain -= chunk
+		}
+
+		if r.Cmp(n) < 0 {
+			return r, nil
+		}
+	}
+}
+
 /**Fill given slice with zeros.*/
 func Erase(sensitive []byte) {
 	for i := range sensitive {
@@ -109,11 +188,12 @@ func Erase(sensitive []byte) {
 
 type shuffleHelper struct {
 	rand []int
-	array []byte
+	n int
+	swap func(i, j int)
 }
 
 func (self *shuffleHelper) Len() int {
-	return len(self.array)
+	return self.n
 }
 
 func (self *shuffleHelper) Less(i, j int) bool {
@@ -121,19 +201,21 @@ func (self *shuffleHelper) Less(i, j int) bool {
 }
 
 func (self *shuffleHelper) Swap(i, j int) {
-	self.array[i], self.array[j] = self.array[j], self.array[i]
+	self.swap(i, j)
 	self.rand[i], self.rand[j] = self.rand[j], self.rand[i]	
 }
 
-func SecureShuffleBytes(array []byte, rng ByteSource) error {
-	n := len(array)
+/*Shuffle n elements by sorting them on a distinct random 16bit integer.
+This matches secureShuffle() in the TypeScript code.*/
+func secureShuffle(n int, swap func(i, j int), rng ByteSource) error {
 	if n > 0x7fff {
-		return errors.New("SecureShuffleBytes: array too large")
+		return errors.New("SecureShuffle: array too large")
 	}
 
 	sh := shuffleHelper{
 		rand: make([]int, n),
-		array: array,
+		n: n,
+		swap: swap,
 	}
 
 	//Create a random integer for every element of the array.
@@ -143,7 +225,7 @@ func SecureShuffleBytes(array []byte, rng ByteSource) error {
 	var b1, b2 byte
 	used := make(map[int]bool)
 
-	for i := range array {
+	for i := 0; i < n; i++ {
 		for {
 			b1, err = rng.NextByte()
 			if err != nil {
@@ -171,6 +253,18 @@ func SecureShuffleBytes(array []byte, rng ByteSource) error {
 	return nil
 }
 
+func SecureShuffleBytes(array []byte, rng ByteSource) error {
+	return secureShuffle(len(array), func(i, j int) {
+		array[i], array[j] = array[j], array[i]
+	}, rng)
+}
+
+func SecureShuffleStrings(array []string, rng ByteSource) error {
+	return secureShuffle(len(array), func(i, j int) {
+		array[i], array[j] = array[j], array[i]
+	}, rng)
+}
+
 /**Create an array of increasing byte values.*/
 func ByteSequence(start byte, count int) []byte {
 	res := make([]byte, count)
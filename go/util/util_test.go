@@ -6,7 +6,7 @@ import (
 	"io"
 	"encoding/hex"
 	"bytes"
-
+	"math/big"
 )
 
 /**Cycle through all byte values*/
@@ -185,6 +185,148 @@ func TestUnbiasedSmallInt(t *testing.T) {
 	assert.Equal(255, spotcheck_UnbiasedSmallInt(255, 256))
 }
 
+/**Cycle through all 16bit values (big endian)*/
+type cycle16_byte_source struct {
+	next uint32
+	lowByte bool
+}
+
+func (self *cycle16_byte_source) NextByte() (byte, error) {
+	if self.next > 0xFFFF {
+		return 0, io.EOF
+	}
+
+	var b byte
+	if self.lowByte {
+		b = byte(self.next)
+		self.next++
+	} else {
+		b = byte(self.next >> 8)
+	}
+	self.lowByte = !self.lowByte
+
+	return b, nil
+}
+
+func unbiasedBigIntAsInt(source ByteSource, n int) (int, error) {
+	v, err := UnbiasedBigInt(source, big.NewInt(int64(n)))
+	if err != nil {
+		return -1, err
+	}
+	return int(v.Int64()), nil
+}
+
+/*Consume every 16bit value once and check that every result was returned
+equally often.*/
+func hasGoodDistribution16(fn rand_int8_func, n int) bool {
+	src := &cycle16_byte_source{}
+	counts := make([]int, n)
+
+	for {
+		v, err := fn(src, n)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return false
+		}
+		counts[v]++
+	}
+
+	for j := 1; j < n; j++ {
+		if counts[0] != counts[j] || counts[0] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestUnbiasedInt(t *testing.T) {
+	assert := assert.New(t)
+
+	src8 := &cycle_byte_source{
+		maxCycleCount: 9999,
+	}
+
+	//Same as UnbiasedSmallInt for all N <= 256
+	for n := 1; n <= 256; n++ {
+		src8.reset()
+		if !hasGoodDistribution(src8, UnbiasedInt, n) {
+			t.Errorf("UnbiasedInt is non-uniform with n=%d", n)
+			return
+		}
+
+		src8.reset()
+		if !hasGoodDistribution(src8, unbiasedBigIntAsInt, n) {
+			t.Errorf("UnbiasedBigInt is non-uniform with n=%d", n)
+			return
+		}
+	}
+
+	//bad_rand_int8 suffers from modulo bias
+	bad16 := func(source ByteSource, n int) (int, error) {
+		b1, err := source.NextByte()
+		if err != nil {
+			return -1, err
+		}
+		b2, err := source.NextByte()
+		return ((int(b1) << 8) | int(b2)) % n, err
+	}
+	assert.False(hasGoodDistribution16(bad16, 1632))
+
+	//Larger N, including the sizes of the bundled word lists
+	for _, n := range []int{257, 1000, 1631, 1632, 2048, 2752, 4097, 65535, 65536} {
+		assert.True(hasGoodDistribution16(UnbiasedInt, n), "UnbiasedInt n=%d", n)
+		assert.True(hasGoodDistribution16(unbiasedBigIntAsInt, n), "UnbiasedBigInt n=%d", n)
+	}
+
+	//n=1632 needs 11 bits. 0xCC 0x40 = 11001100 010 -> 1634 (rejected),
+	// then 0x00 0x40 = 00000000 010 -> 2
+	src := &FixedByteSource{Bytes: []byte{0xCC, 0x40, 0x00, 0x40, 0x20}}
+	v, err := UnbiasedInt(src, 1632)
+	assert.NoError(err)
+	assert.Equal(2, v)
+
+	//only 8 bits left
+	_, err = UnbiasedInt(src, 1632)
+	assert.Equal(io.EOF, err)
+
+	//n == 1 consumes nothing
+	v, err = UnbiasedInt(src, 1)
+	assert.NoError(err)
+	assert.Equal(0, v)
+
+	_, err = UnbiasedInt(src, 0)
+	assert.NotNil(err)
+}
+
+func TestUnbiasedBigInt(t *testing.T) {
+	assert := assert.New(t)
+
+	//n = 2^40 + 1 needs 41 bits: 32 then 9.
+	n := new(big.Int).Lsh(big.NewInt(1), 40)
+	n.Add(n, big.NewInt(1))
+
+	//first draw is all ones (rejected), second is 1 followed by zeros (== n-1)
+	input := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00}
+	v, err := UnbiasedBigInt(&FixedByteSource{Bytes: input}, n)
+	assert.NoError(err)
+	assert.Equal("1099511627776", v.String())
+
+	//exhausted
+	_, err = UnbiasedBigInt(&FixedByteSource{Bytes: input[0:8]}, n)
+	assert.Equal(io.EOF, err)
+
+	v, err = UnbiasedBigInt(&FixedByteSource{}, big.NewInt(1))
+	assert.NoError(err)
+	assert.Equal(int64(0), v.Int64())
+
+	_, err = UnbiasedBigInt(&FixedByteSource{}, big.NewInt(0))
+	assert.NotNil(err)
+	_, err = UnbiasedBigInt(&FixedByteSource{}, big.NewInt(-5))
+	assert.NotNil(err)
+}
+
 //Make sure I'm using hmac and sha256 correctly
 func TestHmacSha256(t *testing.T) {
 	assert := assert.New(t)
@@ -424,4 +566,20 @@ func TestSecureShuffleBytes(t *testing.T) {
 	//The above result was verified with a simple python program.
 }
 
+func TestSecureShuffleStrings(t *testing.T) {
+	assert := assert.New(t)
+
+	//same random input as TestSecureShuffleBytes
+	src := &FixedByteSource{
+		Bytes: []byte{0x01,0x09, 0x01,0x06, 0x01,0xA1, 0x02,0x03, 0x01,0x05, 0x01,0x06, 0x01,0xA0, 0x01,0x03},
+	}
 
+	a := []string{"a", "b", "c", "d", "e", "f", "g"}
+	err := SecureShuffleStrings(a, src)
+	assert.Nil(err)
+	assert.Equal([]string{"g", "e", "b", "a", "f", "c", "d"}, a)
+
+	//exhausted
+	err = SecureShuffleStrings(a, src)
+	assert.NotNil(err)
+}
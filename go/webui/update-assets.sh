@@ -0,0 +1,44 @@
+#!/bin/bash
+
+set -e  #halt on error
+
+#Copy the calculator pages from website/ into assets/ (embedded into passn by
+# webui.go), compile their scripts with website/type1/compile.sh and record
+# their hashes in assets.sha256.  Run go/type1-wasm/build.sh first for the
+# optional wasm backend.
+
+cd "$(dirname "$0")"
+site=../../website
+
+if ! command -v tsc > /dev/null
+then
+	echo "Error: tsc (TypeScript) is needed to compile the calculator pages"
+	exit 1
+fi
+
+rm -rf assets
+mkdir -p assets/type1 assets/js assets/img
+
+#-L: website/js/module-loader.js is a symlink
+cp -L $site/js/module-loader.js assets/js/
+cp $site/img/* assets/img/
+cp $site/type1/*.html $site/type1/mbcrypt_webworker_v*.js assets/type1/
+
+#straight into assets so the embedded scripts always come from the .ts
+out=$(pwd)/assets/type1
+(cd $site/type1 && ./compile.sh build "$out")
+
+#optional: calc.html?backend=wasm
+for f in passillion_type1.wasm wasm_exec.js
+do
+	if [ -f $site/type1/$f ]
+	then
+		cp $site/type1/$f assets/type1/
+	else
+		echo "Warning: $site/type1/$f does not exist (see go/type1-wasm/build.sh)"
+	fi
+done
+
+(cd assets && find . -type f | sed 's#^\./##' | LC_ALL=C sort | xargs sha256sum) > assets.sha256
+
+echo "Updated assets and assets.sha256"
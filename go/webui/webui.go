@@ -0,0 +1,173 @@
+/*
+A self-hosted copy of the web calculator (website/type1 calc and create
+pages) for `passn web`.  The pages are embedded into the binary so they
+work offline and cannot be changed by whoever controls the network.
+
+The embedded files are copied by update-assets.sh which also records their
+SHA-256 in assets.sha256.  Handler() refuses to serve if the two disagree so
+an edited or missing asset is noticed.
+*/
+package webui
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//go:embed assets
+var gAssets embed.FS
+
+//go:embed assets.sha256
+var gManifest string
+
+//The page shown for "/"
+const IndexPage = "/type1/calc.html"
+
+/*
+Only same-origin scripts, styles and workers.  The pages use inline <style>
+and the wasm backend needs 'wasm-unsafe-eval'.  Nothing may be sent anywhere.
+*/
+const ContentSecurityPolicy = "default-src 'none'; " +
+	"script-src 'self' 'wasm-unsafe-eval'; " +
+	"worker-src 'self'; " +
+	"connect-src 'self'; " +
+	"style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self'; " +
+	"base-uri 'none'; " +
+	"form-action 'none'; " +
+	"frame-ancestors 'none'"
+
+//Parse sha256sum output: "<hex>  <path>" per line.
+func parseManifest(manifest string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	for i, line := range strings.Split(manifest, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || len(fields[0]) != sha256.Size * 2 {
+			return nil, fmt.Errorf("assets.sha256 line %d is malformed", i + 1)
+		}
+		hashes[fields[1]] = strings.ToLower(fields[0])
+	}
+	return hashes, nil
+}
+
+/*
+Every file in fsys must be listed in the manifest with the same hash and
+every listed file must exist.
+*/
+func verifyAssets(fsys fs.FS, manifest string) error {
+	expected, err := parseManifest(manifest)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	seen := make(map[string]bool)
+
+	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		h := sha256.Sum256(content)
+		want, listed := expected[path]
+		if !listed {
+			problems = append(problems, path + " is not in assets.sha256")
+		} else if want != hex.EncodeToString(h[:]) {
+			problems = append(problems, path + " has the wrong hash")
+		}
+		seen[path] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for path := range expected {
+		if !seen[path] {
+			problems = append(problems, path + " is missing")
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("embedded assets do not match assets.sha256 (run update-assets.sh): %s", strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+/*
+Check the embedded assets against assets.sha256.
+*/
+func VerifyAssets() error {
+	sub, err := fs.Sub(gAssets, "assets")
+	if err != nil {
+		return err
+	}
+	return verifyAssets(sub, gManifest)
+}
+
+//Serve fsys with strict security headers.
+func newHandler(fsys fs.FS) http.Handler {
+	files := http.FileServer(http.FS(fsys))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("Content-Security-Policy", ContentSecurityPolicy)
+		h.Set("Referrer-Policy", "no-referrer")
+		h.Set("Cache-Control", "no-store")
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("X-Frame-Options", "DENY")
+		h.Set("Cross-Origin-Opener-Policy", "same-origin")
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			h.Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, IndexPage, http.StatusFound)
+			return
+		}
+
+		//no directory listings
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		files.ServeHTTP(w, r)
+	})
+}
+
+/*
+The web calculator.  Returns an error if the embedded assets fail VerifyAssets().
+*/
+func Handler() (http.Handler, error) {
+	if err := VerifyAssets(); err != nil {
+		return nil, err
+	}
+
+	sub, err := fs.Sub(gAssets, "assets")
+	if err != nil {
+		return nil, err
+	}
+
+	return newHandler(sub), nil
+}
@@ -0,0 +1,121 @@
+package webui
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"strings"
+	"testing/fstest"
+)
+
+func Test_VerifyAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	//the committed assets match the committed manifest
+	assert.NoError(VerifyAssets())
+
+	fsys := fstest.MapFS{
+		"a.txt": {Data: []byte("abc")},
+		"d/b.txt": {Data: []byte("")},
+	}
+	manifest := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad  a.txt\n" +
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  d/b.txt\n"
+	assert.NoError(verifyAssets(fsys, manifest))
+
+	//modified
+	fsys["a.txt"] = &fstest.MapFile{Data: []byte("abd")}
+	err := verifyAssets(fsys, manifest)
+	assert.Error(err)
+	assert.Contains(err.Error(), "a.txt has the wrong hash")
+	fsys["a.txt"] = &fstest.MapFile{Data: []byte("abc")}
+
+	//extra file
+	fsys["evil.js"] = &fstest.MapFile{Data: []byte("x")}
+	err = verifyAssets(fsys, manifest)
+	assert.Error(err)
+	assert.Contains(err.Error(), "evil.js is not in assets.sha256")
+	delete(fsys, "evil.js")
+
+	//missing file
+	delete(fsys, "d/b.txt")
+	err = verifyAssets(fsys, manifest)
+	assert.Error(err)
+	assert.Contains(err.Error(), "d/b.txt is missing")
+
+	assert.Error(verifyAssets(fsys, "nonsense\n"))
+}
+
+func Test_Handler(t *testing.T) {
+	assert := assert.New(t)
+
+	handler, err := Handler()
+	assert.NoError(err)
+
+	get := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	w := get("GET", "/type1/calc.html")
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Contains(w.Body.String(), "CalcPass Type 1")
+	assert.Equal(ContentSecurityPolicy, w.Header().Get("Content-Security-Policy"))
+	assert.Equal("no-referrer", w.Header().Get("Referrer-Policy"))
+	assert.Equal("no-store", w.Header().Get("Cache-Control"))
+	assert.Equal("nosniff", w.Header().Get("X-Content-Type-Options"))
+
+	w = get("GET", "/")
+	assert.Equal(http.StatusFound, w.Code)
+	assert.Equal(IndexPage, w.Header().Get("Location"))
+
+	assert.Equal(http.StatusOK, get("HEAD", "/js/module-loader.js").Code)
+	assert.Equal(http.StatusNotFound, get("GET", "/type1/").Code)
+	assert.Equal(http.StatusNotFound, get("GET", "/nope.html").Code)
+	assert.Equal(http.StatusMethodNotAllowed, get("POST", "/type1/calc.html").Code)
+
+	//headers are set on errors too
+	assert.Equal("no-store", get("GET", "/nope.html").Header().Get("Cache-Control"))
+}
+
+func Test_PageScripts(t *testing.T) {
+	assert := assert.New(t)
+
+	//compiled from the TypeScript by update-assets.sh
+	if _, err := fs.Stat(gAssets, "assets/type1/calc.js"); err != nil {
+		t.Skip("calc.js and create.js are not embedded: run update-assets.sh with tsc installed")
+	}
+
+	handler, err := Handler()
+	assert.NoError(err)
+
+	scriptSrc := regexp.MustCompile(`<script[^>]*\ssrc="([^"]+)"`)
+	nPages := 0
+
+	err = fs.WalkDir(gAssets, "assets", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".html" {
+			return err
+		}
+		nPages++
+
+		html, err := fs.ReadFile(gAssets, p)
+		if err != nil {
+			return err
+		}
+		page := strings.TrimPrefix(p, "assets")
+
+		for _, m := range scriptSrc.FindAllStringSubmatch(string(html), -1) {
+			src := path.Join(path.Dir(page), m[1])
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", src, nil))
+			assert.Equal(http.StatusOK, w.Code, "%s loads %s", page, src)
+		}
+		return nil
+	})
+	assert.NoError(err)
+	assert.True(nPages >= 2)
+}
@@ -0,0 +1,205 @@
+package wordlist
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/cruxic/passillion/go/util"
+	"io"
+	"sort"
+	"strings"
+)
+
+//Words which should never be printed on a card.
+var gBuiltinBlocklist = []string{
+	"anal", "anus", "arse", "ass", "bitch", "boob", "butt", "clit", "cock",
+	"coon", "crap", "cum", "cunt", "damn", "dick", "dike", "dildo", "dyke",
+	"fag", "fart", "fuck", "gook", "homo", "jap", "jism", "jizz", "kike",
+	"kill", "nazi", "nigga", "nigger", "paki", "pee", "penis", "piss",
+	"poop", "porn", "prick", "pube", "puss", "pussy", "rape", "scum", "sex",
+	"sexy", "shit", "slut", "spic", "tit", "tits", "turd", "twat", "wank",
+	"whore", "wop",
+}
+
+/*
+Controls how Build() turns a raw dictionary into a word list.
+*/
+type BuildOptions struct {
+	//Allowed word length (inclusive)
+	MinLen, MaxLen int
+
+	//Every letter of a word must be in this set
+	Charset string
+
+	//Additional words to reject (on top of the builtin profanity list)
+	Blocklist []string
+
+	//Reject capitalized entries such as "Ryan" or "Turk"
+	DropProperNouns bool
+
+	//Keep only the first (alphabetically) of words with the same HomophoneKey
+	// ("see" but not "sea")
+	DedupeHomophones bool
+
+	//Reject plurals of other words ("cats" when "cat" is present)
+	DedupePlurals bool
+
+	//Reject words closer than this edit distance to an already accepted word.
+	// 0 or 1 disables the check.
+	MinEditDistance int
+
+	//If non-zero, choose this many words with a shuffle driven by Seed
+	Count int
+	Seed []byte
+}
+
+func DefaultBuildOptions() BuildOptions {
+	return BuildOptions{
+		MinLen: 3,
+		MaxLen: 5,
+		Charset: "abcdefghijklmnopqrstuvwxyz",
+		DropProperNouns: true,
+		DedupeHomophones: true,
+		DedupePlurals: true,
+		MinEditDistance: 2,
+	}
+}
+
+/*
+Filter a raw dictionary (eg from word-lists/dump-aspell-dictionary.sh) into a
+sorted list of card words.  Each entry of raw may hold several words
+separated by whitespace.  The result depends only on the input and the
+options so that it can be regenerated and audited.
+*/
+func Build(raw []string, opt BuildOptions) ([]string, error) {
+	if opt.MinLen < 1 || opt.MaxLen < opt.MinLen {
+		return nil, errors.New("invalid word length range")
+	}
+
+	blocked := make(map[string]bool)
+	for _, list := range [][]string{gBuiltinBlocklist, opt.Blocklist} {
+		for _, word := range list {
+			blocked[strings.ToLower(strings.TrimSpace(word))] = true
+		}
+	}
+
+	//Length, character set, proper nouns, blocklist and exact duplicates
+	candidates := make(map[string]bool)
+	for _, line := range raw {
+		//"aspell expand" gives several forms per line, eg "cat cats"
+		for _, word := range strings.Fields(line) {
+			if opt.DropProperNouns && word[0] >= 'A' && word[0] <= 'Z' {
+				continue
+			}
+
+			word = strings.ToLower(word)
+			n := len([]rune(word))
+			if n < opt.MinLen || n > opt.MaxLen {
+				continue
+			}
+
+			ok := true
+			for _, c := range word {
+				if !strings.ContainsRune(opt.Charset, c) {
+					ok = false
+					break
+				}
+			}
+
+			if ok && !blocked[word] {
+				candidates[word] = true
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(candidates))
+	for word := range candidates {
+		sorted = append(sorted, word)
+	}
+	sort.Strings(sorted)
+
+	var words []string
+	sounds := make(map[string]bool)
+
+	for _, word := range sorted {
+		if opt.DedupePlurals {
+			if strings.HasSuffix(word, "s") && candidates[word[:len(word)-1]] {
+				continue
+			}
+			if strings.HasSuffix(word, "es") && candidates[word[:len(word)-2]] {
+				continue
+			}
+		}
+
+		if opt.DedupeHomophones {
+			key := HomophoneKey(word)
+			if sounds[key] {
+				continue
+			}
+			sounds[key] = true
+		}
+
+		if opt.MinEditDistance > 1 {
+			tooClose := false
+			for _, other := range words {
+				if EditDistance(word, other) < opt.MinEditDistance {
+					tooClose = true
+					break
+				}
+			}
+			if tooClose {
+				continue
+			}
+		}
+
+		words = append(words, word)
+	}
+
+	if opt.Count > 0 {
+		if opt.Count > len(words) {
+			return nil, fmt.Errorf("only %d words remain after filtering; %d requested", len(words), opt.Count)
+		}
+
+		if len(opt.Seed) == 0 {
+			return nil, errors.New("a seed is required to choose words")
+		}
+
+		rng := util.NewHmacCounterByteSource(opt.Seed, 0xFFFFFFFF)
+		if err := util.SecureShuffleStrings(words, rng); err != nil {
+			return nil, err
+		}
+
+		words = words[0:opt.Count]
+		sort.Strings(words)
+	}
+
+	return words, nil
+}
+
+/*
+SHA-256 of the canonical form of a word list: every word followed by "\n".
+*/
+func Hash(words []string) string {
+	sha := sha256.New()
+	for _, word := range words {
+		sha.Write([]byte(word + "\n"))
+	}
+	return hex.EncodeToString(sha.Sum(nil))
+}
+
+/*
+Write the list in canonical form: header lines beginning with "--" (which
+Parse() skips) giving the count and Hash(), then one word per line.
+*/
+func Write(w io.Writer, words []string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "-- passillion word list\n")
+	fmt.Fprintf(bw, "-- count %d\n", len(words))
+	fmt.Fprintf(bw, "-- sha256 %s\n", Hash(words))
+	for _, word := range words {
+		fmt.Fprintf(bw, "%s\n", word)
+	}
+	return bw.Flush()
+}
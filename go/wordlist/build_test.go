@@ -0,0 +1,87 @@
+package wordlist
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"bytes"
+	"strings"
+)
+
+func Test_Build(t *testing.T) {
+	assert := assert.New(t)
+
+	raw := []string{
+		"zebra", "Ryan", "Turk", "cat", "cats", "box", "boxes", "sea", "see",
+		"it's", "ox", "elephant", "café", "fuck", "  dog  ", "dog", "tree",
+		"", "tree trees Trees", "bat", "bet", "hound",
+	}
+
+	//defaults drop near-duplicates and homophones
+	opt := DefaultBuildOptions()
+	words, err := Build(raw, opt)
+	assert.NoError(err)
+	assert.Equal([]string{"bat", "box", "dog", "hound", "sea", "tree", "zebra"}, words)
+
+	//homophones only: bet and cat stay
+	opt.MinEditDistance = 0
+	words, err = Build(raw, opt)
+	assert.NoError(err)
+	assert.Equal([]string{"bat", "bet", "box", "cat", "dog", "hound", "sea", "tree", "zebra"}, words)
+
+	//everything off
+	opt.DropProperNouns = false
+	opt.DedupeHomophones = false
+	opt.DedupePlurals = false
+	words, err = Build(raw, opt)
+	assert.NoError(err)
+	assert.Equal([]string{"bat", "bet", "box", "boxes", "cat", "cats", "dog", "hound", "ryan", "sea", "see", "tree", "trees", "turk", "zebra"}, words)
+
+	//blocklist and edit distance
+	opt.Blocklist = []string{"Ryan", "turk"}
+	opt.MinEditDistance = 2
+	words, err = Build(raw, opt)
+	assert.NoError(err)
+	assert.Equal([]string{"bat", "box", "boxes", "cats", "dog", "hound", "sea", "tree", "zebra"}, words)
+
+	//choose from a seed
+	opt = DefaultBuildOptions()
+	opt.Count = 3
+	opt.Seed = []byte("seed")
+	words, err = Build(raw, opt)
+	assert.NoError(err)
+	assert.Equal(3, len(words))
+	again, err := Build(raw, opt)
+	assert.NoError(err)
+	assert.Equal(words, again)
+
+	opt.Seed = nil
+	_, err = Build(raw, opt)
+	assert.Error(err)
+
+	opt.Seed = []byte("seed")
+	opt.Count = 8
+	_, err = Build(raw, opt)
+	assert.Error(err)
+
+	opt = DefaultBuildOptions()
+	opt.MaxLen = 0
+	_, err = Build(raw, opt)
+	assert.Error(err)
+}
+
+func Test_Write(t *testing.T) {
+	assert := assert.New(t)
+
+	words := []string{"box", "cat"}
+	//echo -ne "box\ncat\n" | sha256sum
+	assert.Equal("d15b09574bd1b46c3e2f07913c344dba9b75857faf3544ddf4ece5d3c5b9d873", Hash(words))
+
+	var buf bytes.Buffer
+	assert.NoError(Write(&buf, words))
+	assert.Equal("-- passillion word list\n-- count 2\n-- sha256 " + Hash(words) + "\nbox\ncat\n", buf.String())
+
+	//Parse skips the header
+	parsed, err := Parse(strings.NewReader(buf.String()))
+	assert.NoError(err)
+	assert.Equal(words, parsed)
+}
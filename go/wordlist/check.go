@@ -0,0 +1,269 @@
+package wordlist
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+A word which can be found inside another word (eg "art" in "part") or across
+the boundary of two other words typed without a space (eg "termite" in
+"lobster"+"miter").  Such words make a password ambiguous to read back.
+*/
+type Overlap struct {
+	Word string
+
+	//The word(s) in which it was found
+	Within []string
+}
+
+/*
+Quality measurements of a candidate word list.  See Analyze().
+*/
+type Report struct {
+	Count int
+
+	//Smallest edit distance between any two words and the pairs at that distance
+	MinEditDistance int
+	ClosestPairs [][2]string
+
+	//Smallest N such that every word is unique in its first N letters
+	// (0 if the list contains duplicates).
+	UniquePrefixLen int
+
+	//Groups of words sharing a phonetic code
+	SoundexCollisions [][]string
+	MetaphoneCollisions [][]string
+
+	Overlaps []Overlap
+
+	//Words containing characters other than printable ASCII
+	NonASCII []string
+}
+
+/*
+Limits which a word list must satisfy.  A negative value disables a check.
+*/
+type Thresholds struct {
+	//Every pair of words must differ by at least this many edits
+	MinEditDistance int
+
+	//Words must be unique in their first N letters
+	MaxUniquePrefixLen int
+
+	//Maximum number of words involved in a phonetic collision
+	MaxSoundexCollisions int
+	MaxMetaphoneCollisions int
+
+	//Maximum number of overlapping words
+	MaxOverlaps int
+
+	//Maximum number of non-ASCII words
+	MaxNonASCII int
+}
+
+/*
+Only duplicates and non-ASCII words fail by default: the bundled lists have
+many phonetic collisions (Metaphone and Soundex are too coarse for short
+words) and overlaps, which are reported for review.
+*/
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		MinEditDistance: 1,
+		MaxUniquePrefixLen: -1,
+		MaxSoundexCollisions: -1,
+		MaxMetaphoneCollisions: -1,
+		MaxOverlaps: -1,
+		MaxNonASCII: 0,
+	}
+}
+
+/*
+findOverlaps only reports a word spanning two others if it takes at least
+this many letters from each.  With fewer, most short words would be reported
+(eg "tar" in "cat"+"arm").
+*/
+const MinBoundaryOverlap = 3
+
+func phoneticCollisions(words []string, code func(string) string) [][]string {
+	groups := make(map[string][]string)
+	for _, word := range words {
+		key := code(word)
+		groups[key] = append(groups[key], word)
+	}
+
+	var res [][]string
+	for _, group := range groups {
+		if len(group) > 1 {
+			res = append(res, group)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i][0] < res[j][0]
+	})
+
+	return res
+}
+
+func uniquePrefixLen(words []string) int {
+	maxLen := 0
+	for _, word := range words {
+		if len(word) > maxLen {
+			maxLen = len(word)
+		}
+	}
+
+	for n := 1; n <= maxLen; n++ {
+		seen := make(map[string]bool)
+		unique := true
+		for _, word := range words {
+			p := word
+			if len(p) > n {
+				p = p[0:n]
+			}
+			if seen[p] {
+				unique = false
+				break
+			}
+			seen[p] = true
+		}
+
+		if unique {
+			return n
+		}
+	}
+
+	return 0
+}
+
+func findOverlaps(words []string) []Overlap {
+	//index every proper prefix and suffix
+	prefixes := make(map[string][]string)
+	suffixes := make(map[string][]string)
+	for _, word := range words {
+		for i := 1; i <= len(word); i++ {
+			prefixes[word[0:i]] = append(prefixes[word[0:i]], word)
+			suffixes[word[len(word)-i:]] = append(suffixes[word[len(word)-i:]], word)
+		}
+	}
+
+	var res []Overlap
+	for _, word := range words {
+		var within []string
+
+		//inside a single longer word
+		for _, other := range words {
+			if other != word && strings.Contains(other, word) {
+				within = append(within, other)
+			}
+		}
+
+		//across the boundary of two words: word = x + y
+		for i := MinBoundaryOverlap; i <= len(word) - MinBoundaryOverlap && len(within) == 0; i++ {
+			x, y := word[0:i], word[i:]
+			for _, a := range suffixes[x] {
+				if a == word {
+					continue
+				}
+				for _, b := range prefixes[y] {
+					if b != word {
+						within = append(within, a + "+" + b)
+						break
+					}
+				}
+				if len(within) > 0 {
+					break
+				}
+			}
+		}
+
+		if len(within) > 0 {
+			res = append(res, Overlap{Word: word, Within: within})
+		}
+	}
+
+	return res
+}
+
+/*
+Measure a word list.  Edit distance is computed for every pair so this is
+quadratic in the number of words.
+*/
+func Analyze(words []string) Report {
+	r := Report{
+		Count: len(words),
+		MinEditDistance: -1,
+	}
+
+	for i := 0; i < len(words); i++ {
+		for j := i + 1; j < len(words); j++ {
+			d := EditDistance(words[i], words[j])
+			if r.MinEditDistance < 0 || d < r.MinEditDistance {
+				r.MinEditDistance = d
+				r.ClosestPairs = nil
+			}
+			if d == r.MinEditDistance {
+				r.ClosestPairs = append(r.ClosestPairs, [2]string{words[i], words[j]})
+			}
+		}
+	}
+
+	r.UniquePrefixLen = uniquePrefixLen(words)
+	r.SoundexCollisions = phoneticCollisions(words, Soundex)
+	r.MetaphoneCollisions = phoneticCollisions(words, Metaphone)
+	r.Overlaps = findOverlaps(words)
+
+	for _, word := range words {
+		for _, c := range word {
+			if c < ' ' || c > '~' {
+				r.NonASCII = append(r.NonASCII, word)
+				break
+			}
+		}
+	}
+
+	return r
+}
+
+func countWords(groups [][]string) int {
+	n := 0
+	for _, g := range groups {
+		n += len(g)
+	}
+	return n
+}
+
+/*
+Return a description of every threshold the report violates.
+*/
+func (self *Report) Failures(th Thresholds) []string {
+	var res []string
+
+	if th.MinEditDistance >= 0 && self.MinEditDistance >= 0 && self.MinEditDistance < th.MinEditDistance {
+		res = append(res, fmt.Sprintf("minimum edit distance is %d (need %d)", self.MinEditDistance, th.MinEditDistance))
+	}
+
+	if th.MaxUniquePrefixLen >= 0 && (self.UniquePrefixLen == 0 || self.UniquePrefixLen > th.MaxUniquePrefixLen) {
+		res = append(res, fmt.Sprintf("words are not unique in their first %d letters", th.MaxUniquePrefixLen))
+	}
+
+	if n := countWords(self.SoundexCollisions); th.MaxSoundexCollisions >= 0 && n > th.MaxSoundexCollisions {
+		res = append(res, fmt.Sprintf("%d words share a Soundex code (max %d)", n, th.MaxSoundexCollisions))
+	}
+
+	if n := countWords(self.MetaphoneCollisions); th.MaxMetaphoneCollisions >= 0 && n > th.MaxMetaphoneCollisions {
+		res = append(res, fmt.Sprintf("%d words share a Metaphone code (max %d)", n, th.MaxMetaphoneCollisions))
+	}
+
+	if th.MaxOverlaps >= 0 && len(self.Overlaps) > th.MaxOverlaps {
+		res = append(res, fmt.Sprintf("%d words occur inside other words (max %d)", len(self.Overlaps), th.MaxOverlaps))
+	}
+
+	if th.MaxNonASCII >= 0 && len(self.NonASCII) > th.MaxNonASCII {
+		res = append(res, fmt.Sprintf("%d words are not plain ASCII (max %d)", len(self.NonASCII), th.MaxNonASCII))
+	}
+
+	return res
+}
@@ -0,0 +1,59 @@
+package wordlist
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Analyze(t *testing.T) {
+	assert := assert.New(t)
+
+	words := []string{"cat", "arm", "tar", "part", "art", "pair", "pear", "café", "dog", "lobster", "miter", "termite"}
+	r := Analyze(words)
+
+	assert.Equal(12, r.Count)
+	assert.Equal(1, r.MinEditDistance)
+	assert.Equal([][2]string{{"arm", "art"}, {"part", "art"}}, r.ClosestPairs)
+
+	//"part" and "pair" both start with "pa"
+	assert.Equal(3, r.UniquePrefixLen)
+
+	assert.Equal([][]string{{"pair", "pear"}}, r.MetaphoneCollisions)
+	assert.Contains(r.SoundexCollisions, []string{"pair", "pear"})
+
+	//"tar" in "cat"+"arm" takes only one letter of "cat"
+	assert.Equal([]Overlap{
+		{Word: "art", Within: []string{"part"}},
+		{Word: "termite", Within: []string{"lobster+miter"}},
+	}, r.Overlaps)
+
+	assert.Equal([]string{"café"}, r.NonASCII)
+
+	failures := r.Failures(DefaultThresholds())
+	assert.Equal([]string{
+		"1 words are not plain ASCII (max 0)",
+	}, failures)
+
+	th := DefaultThresholds()
+	th.MaxMetaphoneCollisions = 0
+	assert.Equal([]string{"2 words share a Metaphone code (max 0)"}, r.Failures(th)[0:1])
+
+	th = Thresholds{
+		MinEditDistance: 2,
+		MaxUniquePrefixLen: 2,
+		MaxSoundexCollisions: 0,
+		MaxMetaphoneCollisions: -1,
+		MaxOverlaps: 1,
+		MaxNonASCII: -1,
+	}
+	assert.Equal(4, len(r.Failures(th)))
+}
+
+func Test_uniquePrefixLen(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(1, uniquePrefixLen([]string{"abc", "bcd"}))
+	assert.Equal(4, uniquePrefixLen([]string{"abcd", "abce"}))
+	assert.Equal(4, uniquePrefixLen([]string{"abc", "abcd"}))
+	assert.Equal(0, uniquePrefixLen([]string{"abc", "abc"}))
+}
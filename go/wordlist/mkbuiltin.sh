@@ -0,0 +1,23 @@
+#!/bin/bash
+#Regenerate builtin.go from the text files in word-lists/
+
+set -e  #halt on error
+
+cd `dirname $0`
+
+out=builtin.go
+
+echo "// Code generated by mkbuiltin.sh from word-lists/. DO NOT EDIT." > $out
+echo "" >> $out
+echo "package wordlist" >> $out
+
+for pair in "standard:passillion-standard.txt" "aspell4:aspell-4-letter.txt"
+do
+	name=${pair%%:*}
+	file=${pair#*:}
+	echo "" >> $out
+	echo "//word-lists/$file" >> $out
+	echo "const raw_$name = \`" >> $out
+	cat ../../word-lists/$file >> $out
+	echo "\`" >> $out
+done
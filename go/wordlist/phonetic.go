@@ -0,0 +1,310 @@
+package wordlist
+
+import (
+	"strings"
+)
+
+/*
+Classic American Soundex code of a word (eg "robert" -> "R163").
+Non-letters are ignored.  Returns "" if the word has no letters.
+*/
+func Soundex(word string) string {
+	const codes = "01230120022455012623010202"  //a-z
+
+	var res []byte
+	var last byte
+
+	for _, c := range []byte(strings.ToLower(word)) {
+		if c < 'a' || c > 'z' {
+			continue
+		}
+
+		code := codes[c - 'a']
+		if len(res) == 0 {
+			res = append(res, c - 'a' + 'A')
+			last = code
+			continue
+		}
+
+		//h and w do not separate letters with the same code
+		if c == 'h' || c == 'w' {
+			continue
+		}
+
+		if code != '0' && code != last {
+			res = append(res, code)
+			if len(res) == 4 {
+				break
+			}
+		}
+		last = code
+	}
+
+	if len(res) == 0 {
+		return ""
+	}
+
+	for len(res) < 4 {
+		res = append(res, '0')
+	}
+
+	return string(res)
+}
+
+func isVowel(c byte) bool {
+	return c == 'a' || c == 'e' || c == 'i' || c == 'o' || c == 'u'
+}
+
+/*
+Metaphone key of a word (Lawrence Philips' original algorithm, slightly
+simplified).  Words which sound alike, such as "pair" and "pear", usually
+have the same key.  "0" represents "th" and "X" represents "sh".
+*/
+func Metaphone(word string) string {
+	//keep a-z only
+	var w []byte
+	for _, c := range []byte(strings.ToLower(word)) {
+		if c >= 'a' && c <= 'z' {
+			w = append(w, c)
+		}
+	}
+
+	if len(w) == 0 {
+		return ""
+	}
+
+	//initial exceptions
+	switch {
+	case len(w) > 1 && (string(w[0:2]) == "ae" || string(w[0:2]) == "gn" ||
+		string(w[0:2]) == "kn" || string(w[0:2]) == "pn" || string(w[0:2]) == "wr"):
+		w = w[1:]
+	case w[0] == 'x':
+		w[0] = 's'
+	case len(w) > 1 && string(w[0:2]) == "wh":
+		w = append([]byte{'w'}, w[2:]...)
+	}
+
+	n := len(w)
+	at := func(i int) byte {
+		if i < 0 || i >= n {
+			return 0
+		}
+		return w[i]
+	}
+	next := func(i int, s string) bool {
+		return i + len(s) <= n && string(w[i:i + len(s)]) == s
+	}
+
+	var res []byte
+	for i := 0; i < n; i++ {
+		c := w[i]
+
+		//skip duplicate letters except c
+		if i > 0 && c == w[i-1] && c != 'c' {
+			continue
+		}
+
+		switch c {
+		case 'a', 'e', 'i', 'o', 'u':
+			if i == 0 {
+				res = append(res, c - 'a' + 'A')
+			}
+		case 'b':
+			//silent in "-mb"
+			if !(i == n - 1 && at(i-1) == 'm') {
+				res = append(res, 'B')
+			}
+		case 'c':
+			if next(i, "cia") || next(i, "ch") {
+				if at(i-1) == 's' {
+					res = append(res, 'K')
+				} else {
+					res = append(res, 'X')
+				}
+			} else if next(i, "ci") || next(i, "ce") || next(i, "cy") {
+				if at(i-1) != 's' {
+					res = append(res, 'S')
+				}
+			} else {
+				res = append(res, 'K')
+			}
+		case 'd':
+			if next(i, "dge") || next(i, "dgy") || next(i, "dgi") {
+				res = append(res, 'J')
+			} else {
+				res = append(res, 'T')
+			}
+		case 'g':
+			if at(i-1) == 'd' && (at(i+1) == 'e' || at(i+1) == 'i' || at(i+1) == 'y') {
+				//already handled by "dge"
+			} else if at(i+1) == 'h' && i + 2 < n && !isVowel(at(i+2)) {
+				//silent as in "night"
+			} else if next(i, "gn") && (i + 2 == n || next(i, "gned") && i + 4 == n) {
+				//silent as in "sign"
+			} else if (at(i+1) == 'i' || at(i+1) == 'e' || at(i+1) == 'y') && at(i-1) != 'g' {
+				res = append(res, 'J')
+			} else if at(i+1) == 'h' && i + 2 == n {
+				//silent as in "high"
+			} else {
+				res = append(res, 'K')
+			}
+		case 'h':
+			prev := at(i-1)
+			if prev == 'c' || prev == 's' || prev == 'p' || prev == 't' || prev == 'g' {
+				//part of ch, sh, ph, th, gh
+			} else if isVowel(prev) && !isVowel(at(i+1)) {
+				//silent
+			} else {
+				res = append(res, 'H')
+			}
+		case 'k':
+			if at(i-1) != 'c' {
+				res = append(res, 'K')
+			}
+		case 'p':
+			if at(i+1) == 'h' {
+				res = append(res, 'F')
+			} else {
+				res = append(res, 'P')
+			}
+		case 'q':
+			res = append(res, 'K')
+		case 's':
+			if next(i, "sh") || next(i, "sio") || next(i, "sia") {
+				res = append(res, 'X')
+			} else {
+				res = append(res, 'S')
+			}
+		case 't':
+			if next(i, "tia") || next(i, "tio") {
+				res = append(res, 'X')
+			} else if next(i, "th") {
+				res = append(res, '0')
+			} else if !next(i, "tch") {
+				res = append(res, 'T')
+			}
+		case 'v':
+			res = append(res, 'F')
+		case 'w', 'y':
+			if isVowel(at(i+1)) {
+				res = append(res, c - 'a' + 'A')
+			}
+		case 'x':
+			res = append(res, 'K', 'S')
+		case 'z':
+			res = append(res, 'S')
+		default:
+			//f j l m n r
+			res = append(res, c - 'a' + 'A')
+		}
+	}
+
+	return string(res)
+}
+
+/*
+Levenshtein distance: the number of single character insertions, deletions
+or substitutions needed to change a into b.
+*/
+func EditDistance(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	prev := make([]int, len(rb) + 1)
+	cur := make([]int, len(rb) + 1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = prev[j] + 1
+			if cur[j-1] + 1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if prev[j-1] + cost < cur[j] {
+				cur[j] = prev[j-1] + cost
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+/*
+Stricter sound-alike key than Metaphone: the Metaphone key plus the vowel
+sounds of the word.  Metaphone ignores vowels so "bat", "bet" and "but"
+share a key; HomophoneKey keeps them apart while common spellings of the
+same long vowel still match ("sea" and "see", "pane" and "pain", "rode"
+and "road", "write" and "right").
+*/
+func HomophoneKey(word string) string {
+	return Metaphone(word) + "-" + vowelSounds(word)
+}
+
+//Spellings of the same long vowel, mapped to one upper case letter
+var gVowelSpellings = map[string]byte{
+	"ee": 'E', "ea": 'E',
+	"ai": 'A', "ay": 'A', "ei": 'A', "ey": 'A',
+	"oa": 'O', "oe": 'O',
+	"oo": 'U', "ew": 'U', "ue": 'U', "ui": 'U',
+	"ie": 'I',
+}
+
+/*
+The vowel groups of a word, long vowels in upper case.  A single vowel
+before a consonant and a final silent e ("pane") or before "gh" ("night")
+counts as long.
+*/
+func vowelSounds(word string) string {
+	var w []byte
+	for _, c := range []byte(strings.ToLower(word)) {
+		if c >= 'a' && c <= 'z' {
+			w = append(w, c)
+		}
+	}
+
+	n := len(w)
+	vowel := func(i int) bool {
+		//y is a vowel except at the start
+		return i >= 0 && i < n && (isVowel(w[i]) || (w[i] == 'y' && i > 0))
+	}
+
+	//silent final e after vowel-consonant
+	magicE := n >= 3 && w[n-1] == 'e' && !vowel(n-2) && vowel(n-3) && !vowel(n-4)
+	if magicE {
+		n--
+	}
+
+	var res []byte
+	for i := 0; i < n; {
+		if !vowel(i) {
+			i++
+			continue
+		}
+
+		j := i
+		for j < n && vowel(j) {
+			j++
+		}
+
+		group := string(w[i:j])
+		if long, ok := gVowelSpellings[group]; ok {
+			res = append(res, long)
+		} else if len(group) == 1 && ((magicE && j == n - 1) || (j + 2 <= n && string(w[j:j+2]) == "gh")) {
+			res = append(res, group[0] - 'a' + 'A')
+		} else {
+			res = append(res, group...)
+		}
+		i = j
+	}
+
+	return string(res)
+}
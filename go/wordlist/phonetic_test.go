@@ -0,0 +1,72 @@
+package wordlist
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Soundex(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("R163", Soundex("Robert"))
+	assert.Equal("R163", Soundex("Rupert"))
+	assert.Equal("R150", Soundex("Rubin"))
+	assert.Equal("A261", Soundex("Ashcraft"))
+	assert.Equal("T522", Soundex("Tymczak"))
+	assert.Equal("P236", Soundex("Pfister"))
+	assert.Equal("L000", Soundex("Lee"))
+	assert.Equal("", Soundex("123"))
+}
+
+func Test_Metaphone(t *testing.T) {
+	assert := assert.New(t)
+
+	//homophones
+	assert.Equal(Metaphone("pair"), Metaphone("pear"))
+	assert.Equal(Metaphone("knight"), Metaphone("night"))
+	assert.Equal(Metaphone("write"), Metaphone("right"))
+	assert.Equal(Metaphone("phone"), Metaphone("fone"))
+	assert.Equal(Metaphone("sent"), Metaphone("cent"))
+
+	assert.Equal("0NK", Metaphone("think"))
+	assert.Equal("XP", Metaphone("ship"))
+	assert.Equal("SKL", Metaphone("school"))
+	assert.Equal("JJ", Metaphone("judge"))
+	assert.Equal("LM", Metaphone("lamb"))
+	assert.Equal("SKS", Metaphone("sax"))
+	assert.Equal("AX", Metaphone("ache"))
+	assert.NotEqual(Metaphone("cat"), Metaphone("dog"))
+	assert.Equal("", Metaphone("42"))
+}
+
+func Test_HomophoneKey(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(HomophoneKey("sea"), HomophoneKey("see"))
+	assert.Equal(HomophoneKey("meat"), HomophoneKey("meet"))
+	assert.Equal(HomophoneKey("pane"), HomophoneKey("pain"))
+	assert.Equal(HomophoneKey("rode"), HomophoneKey("road"))
+	assert.Equal(HomophoneKey("write"), HomophoneKey("right"))
+	assert.Equal(HomophoneKey("knight"), HomophoneKey("night"))
+	assert.Equal(HomophoneKey("sent"), HomophoneKey("cent"))
+
+	//same Metaphone key, different vowels
+	assert.NotEqual(HomophoneKey("bat"), HomophoneKey("bet"))
+	assert.NotEqual(HomophoneKey("bit"), HomophoneKey("bite"))
+	assert.NotEqual(HomophoneKey("hop"), HomophoneKey("hope"))
+	assert.NotEqual(HomophoneKey("cat"), HomophoneKey("coat"))
+
+	assert.Equal("BT-a", HomophoneKey("bat"))
+	assert.Equal("RT-I", HomophoneKey("rite"))
+}
+
+func Test_EditDistance(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, EditDistance("word", "word"))
+	assert.Equal(1, EditDistance("word", "ward"))
+	assert.Equal(1, EditDistance("word", "words"))
+	assert.Equal(3, EditDistance("kitten", "sitting"))
+	assert.Equal(4, EditDistance("", "abcd"))
+	assert.Equal(4, EditDistance("abcd", ""))
+}
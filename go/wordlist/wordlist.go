@@ -0,0 +1,105 @@
+/*
+Word lists used for cards and generated passwords.
+
+The lists in word-lists/ are compiled into the binary (see mkbuiltin.sh).
+*/
+package wordlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+//Names accepted by Get()
+const (
+	Standard = "standard"
+	Aspell4 = "aspell4"
+)
+
+/*
+Read one word per line.  Blank lines and separator lines beginning with
+"--" are skipped.  A trailing "?" (marking a word still under review) is removed.
+Returns error if a word appears twice.
+*/
+func Parse(r io.Reader) ([]string, error) {
+	var words []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if len(word) == 0 || strings.HasPrefix(word, "--") {
+			continue
+		}
+
+		word = strings.TrimSuffix(word, "?")
+
+		if seen[word] {
+			return nil, fmt.Errorf("duplicate word \"%s\"", word)
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+/*
+Extract every double quoted string from a TypeScript (or JSON) array such
+as website/type1/words34.ts.  Returns error if a word appears twice.
+*/
+func ParseQuoted(r io.Reader) ([]string, error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var words []string
+	seen := make(map[string]bool)
+
+	parts := strings.Split(string(raw), "\"")
+	for i := 1; i < len(parts); i += 2 {
+		word := parts[i]
+		if seen[word] {
+			return nil, fmt.Errorf("duplicate word \"%s\"", word)
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+
+	return words, nil
+}
+
+/*
+Return a builtin list by name ("standard" or "aspell4") or else load
+the list from the file with the given path.  Files ending with ".ts"
+are read with ParseQuoted().
+*/
+func Get(nameOrPath string) ([]string, error) {
+	switch nameOrPath {
+	case Standard:
+		return Parse(strings.NewReader(raw_standard))
+	case Aspell4:
+		return Parse(strings.NewReader(raw_aspell4))
+	}
+
+	f, err := os.Open(nameOrPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(nameOrPath, ".ts") {
+		return ParseQuoted(f)
+	}
+
+	return Parse(f)
+}
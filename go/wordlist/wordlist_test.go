@@ -0,0 +1,51 @@
+package wordlist
+
+import (
+	"testing"
+	"github.com/stretchr/testify/assert"
+	"strings"
+)
+
+func Test_Parse(t *testing.T) {
+	assert := assert.New(t)
+
+	words, err := Parse(strings.NewReader("\nabc\n  def?\n--separator--\n\nghi"))
+	assert.NoError(err)
+	assert.Equal([]string{"abc", "def", "ghi"}, words)
+
+	_, err = Parse(strings.NewReader("abc\ndef\nabc?\n"))
+	assert.Error(err)
+}
+
+func Test_ParseQuoted(t *testing.T) {
+	assert := assert.New(t)
+
+	words, err := ParseQuoted(strings.NewReader("export const WORDS34 = [\n\t\"zulu\",\n\t\"jam\",\n];\n"))
+	assert.NoError(err)
+	assert.Equal([]string{"zulu", "jam"}, words)
+
+	_, err = ParseQuoted(strings.NewReader(`["a", "b", "a"]`))
+	assert.Error(err)
+}
+
+func Test_Get(t *testing.T) {
+	assert := assert.New(t)
+
+	words, err := Get(Standard)
+	assert.NoError(err)
+	assert.Equal(1631, len(words))
+	assert.Equal("twin", words[0])
+
+	words, err = Get(Aspell4)
+	assert.NoError(err)
+	assert.Equal(2752, len(words))
+	assert.Equal("oath", words[0])
+
+	words, err = Get("../../website/type1/words34.ts")
+	assert.NoError(err)
+	assert.Equal(256, len(words))
+	assert.Equal("zulu", words[0])
+
+	_, err = Get("/no/such/file")
+	assert.Error(err)
+}
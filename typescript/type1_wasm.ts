@@ -0,0 +1,76 @@
+/**
+Optional backend which uses the Go type1 package compiled to WebAssembly
+(go/type1-wasm) instead of passillion_type1.ts so that both cannot drift apart.
+Build passillion_type1.wasm and wasm_exec.js with go/type1-wasm/build.sh.
+*/
+
+import {SiteHash} from './passillion_type1';
+
+//Defined by wasm_exec.js and the wasm module
+declare var Go:any;
+declare var WebAssembly:any;
+declare var passillionType1:any;
+
+//The Go functions return an Error object instead of throwing
+function check(res:any): any {
+	if (res instanceof Error)
+		throw res;
+	return res;
+}
+
+function loadScript(url:string): Promise<void> {
+	return new Promise<void>((resolve, reject)=>{
+		let script = document.createElement('script');
+		script.src = url;
+		script.onload = ()=>{resolve();};
+		script.onerror = ()=>{reject(Error('failed to load ' + url));};
+		document.head.appendChild(script);
+	});
+}
+
+/*
+Load wasm_exec.js and the wasm module.  Resolves once passillionType1 is ready.
+*/
+export async function load(wasmExecURL:string, wasmURL:string): Promise<void> {
+	await loadScript(wasmExecURL);
+
+	let ready = new Promise<void>((resolve)=>{
+		(<any>window).onPassillionType1Ready = resolve;
+	});
+
+	let response = await fetch(wasmURL);
+	if (!response.ok)
+		throw Error('failed to load ' + wasmURL);
+	let bytes = await response.arrayBuffer();
+
+	let go = new Go();
+	let result = await WebAssembly.instantiate(bytes, go.importObject);
+	go.run(result.instance);  //resolves when the Go program exits so don't await
+
+	await ready;
+}
+
+export function splitCheckword(passwordWithCheckword:string): Array<string> {
+	return check(passillionType1.splitCheckword(passwordWithCheckword));
+}
+
+export function calcCheckword(password:string): string {
+	return check(passillionType1.calcCheckword(password));
+}
+
+export function isCorrectCheckword(password:string, checkword:string): boolean {
+	return check(passillionType1.isCorrectCheckword(password, checkword));
+}
+
+export function normalizeField(s:string): string {
+	return check(passillionType1.normalizeField(s));
+}
+
+export async function calcSiteHash(password:string, sitename:string, personalization:string): Promise<SiteHash> {
+	let hash = await check(passillionType1.calcSiteHash(password, sitename, personalization));
+	return new SiteHash(hash);
+}
+
+export function getWordCoordinates(hash:SiteHash, nWords:number): Array<string> {
+	return check(passillionType1.getWordCoordinates(hash.hash, nWords));
+}
@@ -1,9 +1,32 @@
 import {MbcryptWorkerManager} from './ts/mbcrypt_workermanager';
 import * as type1 from './ts/passillion_type1';
 import * as mbcrypt_webworker_filename from './mbcrypt_webworker_filename';
+import * as type1_wasm from './ts/type1_wasm';
 
 let gWorkers:MbcryptWorkerManager = null;
 
+//The functions which have a Go implementation (see ts/type1_wasm.ts)
+interface Type1Backend {
+	splitCheckword(passwordWithCheckword:string): Array<string>;
+	calcCheckword(password:string): string;
+	isCorrectCheckword(password:string, checkword:string): boolean;
+	normalizeField(s:string): string;
+	calcSiteHash(password:string, sitename:string, personalization:string): Promise<type1.SiteHash>;
+	getWordCoordinates(hash:type1.SiteHash, nWords:number): Array<string>;
+}
+
+//The TypeScript port unless the page was opened with ?backend=wasm
+let gBackend:Type1Backend = {
+	splitCheckword: type1.splitCheckword,
+	calcCheckword: type1.calcCheckword,
+	isCorrectCheckword: type1.isCorrectCheckword,
+	normalizeField: type1.normalizeField,
+	calcSiteHash: function(password:string, sitename:string, personalization:string) {
+		return type1.calcSiteHash(gWorkers, password, sitename, personalization);
+	},
+	getWordCoordinates: type1.getWordCoordinates,
+};
+
 let gCalculating = false;
 
 /*
@@ -57,10 +80,10 @@ async function _calculate() {
 
 	//
 	// Checkword
-	let tup = type1.splitCheckword(pass);
+	let tup = gBackend.splitCheckword(pass);
 	pass = tup[0];
 	let checkword = tup[1];
-	if (!type1.isCorrectCheckword(pass, checkword)) {
+	if (!gBackend.isCorrectCheckword(pass, checkword)) {
 		showError('Typo or wrong check-word.');
 		Elm('chkwordTip').style.display = 'block';
 		return;
@@ -72,14 +95,14 @@ async function _calculate() {
 	let t1 = new Date().getTime();
 
 	Elm('loading_anim').style.display = 'block';
-	let hash = await type1.calcSiteHash(gWorkers, pass, site, personalization);
+	let hash = await gBackend.calcSiteHash(pass, site, personalization);
 
 	let t2 = new Date().getTime();
 	console.log('Hashing took ' + (t2 - t1) + 'ms');
 
 	Elm('loading_anim').style.display = 'none';
 
-	let coords = type1.getWordCoordinates(hash, 4);
+	let coords = gBackend.getWordCoordinates(hash, 4);
 
 	let html = [];
 	for (let i = 0; i < coords.length; i++) {
@@ -130,16 +153,16 @@ function onPasswordChange() {
 	infoElm.className = 'na';  //remove 'correctCheckword' class
 
 	if (passElm.type == 'text' && pass.length > 0) {
-		let tuple = type1.splitCheckword(pass);
+		let tuple = gBackend.splitCheckword(pass);
 
 		if (pass.length < type1.MinCoordPassLen) {
 			infoElm.firstChild.nodeValue = 'Minimum ' + type1.MinCoordPassLen + ' characters.';
 		}
-		else if (type1.isCorrectCheckword(tuple[0], tuple[1])) {
+		else if (gBackend.isCorrectCheckword(tuple[0], tuple[1])) {
 			infoElm.firstChild.nodeValue = 'Correct check-word!';
 			infoElm.className = 'correctCheckword';
 		} else {
-			infoElm.firstChild.nodeValue = 'Check-word: ' + type1.calcCheckword(pass);
+			infoElm.firstChild.nodeValue = 'Check-word: ' + gBackend.calcCheckword(pass);
 		}
 	}
 	//else leave blank
@@ -189,27 +212,39 @@ class RememberAnimation {
 }
 
 async function onLoad() {
-	gWorkers = new MbcryptWorkerManager(type1.NumThreads, mbcrypt_webworker_filename.FileName);
-	try {
-		await gWorkers.selftest();
-		console.log('mbcrypt self-test passed');
-	} catch (e) {
-		console.log('selftest failed');
-		console.log(e);
-		showError('Javascript self-test failed.  Please try a different web browser.');
-		return;
+	if (window.location.search.indexOf('backend=wasm') != -1) {
+		try {
+			await type1_wasm.load('wasm_exec.js', 'passillion_type1.wasm');
+			gBackend = type1_wasm;
+			console.log('using the WebAssembly backend');
+		} catch (e) {
+			console.log(e);
+			showError('Failed to load the WebAssembly backend.');
+			return;
+		}
+	} else {
+		gWorkers = new MbcryptWorkerManager(type1.NumThreads, mbcrypt_webworker_filename.FileName);
+		try {
+			await gWorkers.selftest();
+			console.log('mbcrypt self-test passed');
+		} catch (e) {
+			console.log('selftest failed');
+			console.log(e);
+			showError('Javascript self-test failed.  Please try a different web browser.');
+			return;
+		}
 	}
 
 	let siteElm = Elm('txtSite');
 	siteElm.addEventListener('blur', function(e) {
-		e.target.value = type1.trimURL(type1.normalizeField(<string>e.target.value));
+		e.target.value = type1.trimURL(gBackend.normalizeField(<string>e.target.value));
 	});
 	siteElm.addEventListener('input', hideError);
 	siteElm.addEventListener('keyup', detectEnterKeypress);
 
 	let persElm = Elm('txtPers');
 	persElm.addEventListener('blur', function(e) {
-		e.target.value = type1.normalizeField(<string>e.target.value);
+		e.target.value = gBackend.normalizeField(<string>e.target.value);
 	});
 	persElm.addEventListener('input', hideError);
 	persElm.addEventListener('keyup', detectEnterKeypress);
@@ -7,6 +7,7 @@ pagename=$1
 if [ -z "$pagename" ]
 then
 	echo "Example: ./compile.sh calc"
+	echo "Or once without watching: ./compile.sh build [output directory]"
 	exit 1
 fi
 
@@ -18,7 +19,15 @@ TSC_OPTIONS="--module amd \
 	--alwaysStrict \
 	--noUnusedLocals"
 
-if [ $pagename == 'worker' ]
+if [ $pagename == 'build' ]
+then
+	#calc.js and create.js once, eg from go/webui/update-assets.sh
+	outdir=${2:-.}
+	for page in calc create
+	do
+		tsc $TSC_OPTIONS --outFile $outdir/$page.js $page.ts
+	done
+elif [ $pagename == 'worker' ]
 then
 	rm -f mbcrypt_webworker_v*.js mbcrypt_webworker_filename.ts
 	tsc $TSC_OPTIONS --outFile temp1.js ts/mbcrypt_webworker.ts
@@ -3,7 +3,7 @@ import * as hex from './ts/hex';
 import * as sha256 from './ts/sha256';
 import * as util from './ts/util';
 import {WORDS34} from './words34';
-import {WordLayout, WordCell, ColumnLetters} from './ts/calcpass_type1';
+import {WordLayout, WordCell, ColumnLetters} from './ts/passillion_type1';
 
 function genSecureRandomBytes(nBytes:number): Uint8Array {
 	var ar = new Uint8Array(nBytes);
@@ -1,2 +1,5 @@
 #https://superuser.com/questions/137957/how-to-convert-aspell-dictionary-to-simple-list-of-words
 aspell -d en dump master | aspell -l en expand > my.dict
+
+#Then filter it into a card word list, for example:
+#  passn wordlist build -in my.dict -min 3 -max 4 -count 256 -seed <hex> -out card-words.txt